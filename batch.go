@@ -0,0 +1,250 @@
+package crowdin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileFailure is a file that failed in a call split into several requests.
+type FileFailure struct {
+	// Name of the file in Crowdin project.
+	Name string
+
+	// Error of the request that carried the file.
+	Err error
+}
+
+// BatchError is returned by AddFile, UpdateFile and UploadTranslations when some of
+// the requests of a split call failed. The result of the successful requests is returned with it.
+type BatchError struct {
+	Failures []FileFailure
+}
+
+func (e *BatchError) Error() string {
+
+	var reasons []string
+	seen := make(map[string]bool)
+	for _, failure := range e.Failures {
+		reason := failure.Err.Error()
+		if !seen[reason] {
+			seen[reason] = true
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return fmt.Sprintf("crowdin: %v files failed: %v", len(e.Failures), strings.Join(reasons, "; "))
+}
+
+// Unwrap returns the errors of the failed requests, so they can be matched with errors.Is.
+func (e *BatchError) Unwrap() []error {
+
+	var errs []error
+	seen := make(map[error]bool)
+	for _, failure := range e.Failures {
+		if !seen[failure.Err] {
+			seen[failure.Err] = true
+			errs = append(errs, failure.Err)
+		}
+	}
+
+	return errs
+}
+
+// batch is a part of the files of a call that fits into one request.
+type batch struct {
+	files   map[string]string
+	sources map[string]FileSource
+	names   []string
+}
+
+// batches splits the files to parts of the batch size, in the order of the names.
+func (crowdin *Crowdin) batches(files map[string]string, sources map[string]FileSource) []batch {
+
+	crowdin.mu.RLock()
+	size := crowdin.batch.size
+	crowdin.mu.RUnlock()
+
+	names := make([]string, 0, len(files)+len(sources))
+	for name := range files {
+		names = append(names, name)
+	}
+	for name := range sources {
+		if _, ok := files[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if size <= 0 || len(names) <= size {
+		return []batch{{files: files, sources: sources, names: names}}
+	}
+
+	var batches []batch
+	for start := 0; start < len(names); start += size {
+
+		end := start + size
+		if end > len(names) {
+			end = len(names)
+		}
+
+		b := batch{names: names[start:end]}
+		for _, name := range b.names {
+			if source, ok := sources[name]; ok {
+				if b.sources == nil {
+					b.sources = make(map[string]FileSource)
+				}
+				b.sources[name] = source
+			} else {
+				if b.files == nil {
+					b.files = make(map[string]string)
+				}
+				b.files[name] = files[name]
+			}
+		}
+		batches = append(batches, b)
+	}
+
+	return batches
+}
+
+// runBatches calls call for every batch, with bounded parallelism, and returns the errors by batch.
+// Batches that didn't start before ctx was done fail with the error of ctx.
+func (crowdin *Crowdin) runBatches(ctx context.Context, batches []batch, call func(ctx context.Context, i int) error) []error {
+
+	crowdin.mu.RLock()
+	parallelism := crowdin.batch.parallelism
+	crowdin.mu.RUnlock()
+
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	errs := make([]error, len(batches))
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i := range batches {
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = call(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+	return errs
+}
+
+// batchError collects the files of the failed batches, or returns nil if all of them succeeded.
+func batchError(batches []batch, errs []error) *BatchError {
+
+	var failures []FileFailure
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		for _, name := range batches[i].names {
+			failures = append(failures, FileFailure{Name: name, Err: err})
+		}
+	}
+
+	if failures == nil {
+		return nil
+	}
+	return &BatchError{Failures: failures}
+}
+
+func (crowdin *Crowdin) addFileBatches(ctx context.Context, options *AddFileOptions, batches []batch) (*AddFileResult, error) {
+
+	results := make([]*AddFileResult, len(batches))
+	errs := crowdin.runBatches(ctx, batches, func(ctx context.Context, i int) error {
+		part := *options
+		part.Files, part.Sources = batches[i].files, batches[i].sources
+		result, err := crowdin.AddFileContext(ctx, &part)
+		results[i] = result
+		return err
+	})
+
+	merged := &AddFileResult{Success: true}
+	for _, result := range results {
+		if result != nil {
+			merged.Stats.Files = append(merged.Stats.Files, result.Stats.Files...)
+		}
+	}
+
+	if err := batchError(batches, errs); err != nil {
+		merged.Success = false
+		merged.Failures = err.Failures
+		return merged, err
+	}
+
+	return merged, nil
+}
+
+func (crowdin *Crowdin) updateFileBatches(ctx context.Context, options *UpdateFileOptions, batches []batch) (*UpdateFileResult, error) {
+
+	results := make([]*UpdateFileResult, len(batches))
+	errs := crowdin.runBatches(ctx, batches, func(ctx context.Context, i int) error {
+		part := *options
+		part.Files, part.Sources = batches[i].files, batches[i].sources
+		result, err := crowdin.UpdateFileContext(ctx, &part)
+		results[i] = result
+		return err
+	})
+
+	merged := &UpdateFileResult{Success: true, Files: make(map[string]string)}
+	for _, result := range results {
+		if result != nil {
+			for name, status := range result.Files {
+				merged.Files[name] = status
+			}
+		}
+	}
+
+	if err := batchError(batches, errs); err != nil {
+		merged.Success = false
+		merged.Failures = err.Failures
+		return merged, err
+	}
+
+	return merged, nil
+}
+
+func (crowdin *Crowdin) uploadTranslationsBatches(ctx context.Context, options *UploadTranslationsOptions, batches []batch) (*UploadTranslationResult, error) {
+
+	results := make([]*UploadTranslationResult, len(batches))
+	errs := crowdin.runBatches(ctx, batches, func(ctx context.Context, i int) error {
+		part := *options
+		part.Files, part.Sources = batches[i].files, batches[i].sources
+		result, err := crowdin.UploadTranslationsContext(ctx, &part)
+		results[i] = result
+		return err
+	})
+
+	merged := &UploadTranslationResult{Success: true}
+	for _, result := range results {
+		if result != nil {
+			merged.Stats.Files = append(merged.Stats.Files, result.Stats.Files...)
+		}
+	}
+
+	if err := batchError(batches, errs); err != nil {
+		merged.Success = false
+		merged.Failures = err.Failures
+		return merged, err
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,115 @@
+package crowdin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCrowdin_AddFile_batches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.batch.size = 2
+	crowdin.batch.parallelism = 2
+
+	var mu sync.Mutex
+	var requests [][]string
+	var running, maxRunning int32
+
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+
+		r.ParseMultipartForm(1 << 20)
+		var names []string
+		var stats []string
+		for key := range r.MultipartForm.File {
+			name := strings.TrimSuffix(strings.TrimPrefix(key, "files["), "]")
+			names = append(names, name)
+			stats = append(stats, fmt.Sprintf(`{"file_id":1,"name":%q,"strings":2,"words":3}`, name))
+		}
+		mu.Lock()
+		requests = append(requests, names)
+		mu.Unlock()
+
+		if len(names) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success":false,"error":{"code":5,"message":"File with the same name already uploaded"}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"stats":{"files":[%v]}}`, strings.Join(stats, ","))
+	})
+
+	sources := make(map[string]FileSource)
+	for _, name := range []string{"a.csv", "b.csv", "c.csv", "d.csv", "e.csv"} {
+		sources[name] = FromReader(name, strings.NewReader("id,text"))
+	}
+
+	result, err := crowdin.AddFile(&AddFileOptions{Sources: sources})
+
+	if len(requests) != 3 {
+		t.Errorf("Expected %v, got %v", 3, len(requests))
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most %v requests at once, got %v", 2, maxRunning)
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected BatchError, got %v", err)
+	}
+	if !errors.Is(err, ErrFileExists) {
+		t.Errorf("Expected %v, got %v", ErrFileExists, err)
+	}
+	if len(batchErr.Failures) != 1 || batchErr.Failures[0].Name != "e.csv" {
+		t.Errorf("Unexpected failures %v", batchErr.Failures)
+	}
+
+	if result == nil || result.Success {
+		t.Fatalf("Expected failed result, got %v", result)
+	}
+	if len(result.Stats.Files) != 4 || result.Stats.Files[0].Strings != 2 || result.Stats.Files[0].Words != 3 {
+		t.Errorf("Unexpected stats %+v", result.Stats.Files)
+	}
+	if len(result.Failures) != 1 {
+		t.Errorf("Expected %v, got %v", 1, len(result.Failures))
+	}
+}
+
+func TestCrowdin_UpdateFile_noBatches(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc("/project-name/update-file", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"success":true,"files":{"a.csv":"updated","b.csv":"updated"}}`))
+	})
+
+	result, err := crowdin.UpdateFile(&UpdateFileOptions{
+		Sources: map[string]FileSource{
+			"a.csv": FromReader("a.csv", strings.NewReader("a")),
+			"b.csv": FromReader("b.csv", strings.NewReader("b")),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected %v, got %v", 1, requests)
+	}
+	if !result.Success || result.Files["b.csv"] != "updated" {
+		t.Errorf("Unexpected %+v", result)
+	}
+}
@@ -0,0 +1,217 @@
+package crowdin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Cache remembers the builds of downloaded translations, so DownloadTranslations, ExportFile
+// and DownloadAndExtract skip the download when nothing was built since the last one.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry of the key, if there is one.
+	Get(key string) (CacheEntry, bool)
+
+	// Put stores the entry of the key.
+	Put(key string, entry CacheEntry) error
+}
+
+// CacheEntry is a download of translations.
+type CacheEntry struct {
+	// Time of the build the files were downloaded from.
+	LastBuild time.Time `json:"last_build"`
+
+	// Hex sha256 of the content of the written files, by local path.
+	Files map[string]string `json:"files"`
+}
+
+// SetCache sets the cache of downloads. Nil disables the caching, which is the default.
+// With a cache, every download first checks the time of the last build with GetExportStatus.
+// Downloads are skipped when the build is the same as the cached one and the local files weren't changed.
+func (crowdin *Crowdin) SetCache(cache Cache) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
+	crowdin.cache = cache
+}
+
+// MemoryCache is a Cache that lives as long as the process.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache - create new empty cache in memory.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]CacheEntry)}
+}
+
+// Get returns the entry of the key, if there is one.
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// Put stores the entry of the key.
+func (c *MemoryCache) Put(key string, entry CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+	return nil
+}
+
+// FileCache is a Cache stored in a json file, so it's kept between runs, e.g. of builds.
+type FileCache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]CacheEntry
+}
+
+// NewFileCache - create the cache stored at path. The file is created on the first Put.
+func NewFileCache(path string) (*FileCache, error) {
+
+	c := &FileCache{path: path, entries: make(map[string]CacheEntry)}
+	if err := readJSONFile(path, &c.entries); err != nil {
+		return nil, err
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]CacheEntry)
+	}
+
+	return c, nil
+}
+
+// Get returns the entry of the key, if there is one.
+func (c *FileCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+// Put stores the entry of the key and writes the whole cache to the file.
+func (c *FileCache) Put(key string, entry CacheEntry) error {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+	return writeJSONFile(c.path, c.entries)
+}
+
+// readJSONFile decodes the json file at path to v. Missing file is left as is.
+func readJSONFile(path string, v interface{}) error {
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("crowdin: invalid file %v: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeJSONFile replaces the file at path with v encoded to json.
+func writeJSONFile(path string, v interface{}) error {
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return createFile(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// settingsKey returns a short digest of the settings that decide where the files are written,
+// e.g. patterns and mappings, so changing them invalidates the cache entry.
+func settingsKey(settings interface{}) string {
+	data, _ := json.Marshal(settings)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// cachedDownload calls download, unless the files it wrote last time are from the current build
+// and weren't changed since. Download returns the paths of the written files. Without cache
+// download is always called.
+func (crowdin *Crowdin) cachedDownload(ctx context.Context, key string, download func() ([]string, error)) (cached bool, err error) {
+
+	crowdin.mu.RLock()
+	cache := crowdin.cache
+	project := crowdin.config.project
+	crowdin.mu.RUnlock()
+
+	if cache == nil {
+		_, err := download()
+		return false, err
+	}
+
+	key = project + "/" + key
+
+	status, err := crowdin.GetExportStatusContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	lastBuild := status.LastBuild.Time
+	if entry, ok := cache.Get(key); ok && !lastBuild.IsZero() && entry.LastBuild.Equal(lastBuild) && entry.unchanged() {
+		crowdin.log("Skipping download of " + key + ", nothing was built since " + lastBuild.String())
+		return true, nil
+	}
+
+	files, err := download()
+	if err != nil || lastBuild.IsZero() {
+		return false, err
+	}
+
+	entry := CacheEntry{LastBuild: lastBuild, Files: make(map[string]string, len(files))}
+	for _, name := range files {
+		hash, err := fileHash(name)
+		if err != nil {
+			return false, err
+		}
+		entry.Files[name] = hex.EncodeToString(hash)
+	}
+
+	if err := cache.Put(key, entry); err != nil {
+		return false, fmt.Errorf("crowdin: cache: %w", err)
+	}
+
+	return false, nil
+}
+
+// unchanged reports whether all the files of the entry still have the cached content.
+func (entry CacheEntry) unchanged() bool {
+
+	if len(entry.Files) == 0 {
+		return false
+	}
+
+	for name, want := range entry.Files {
+		hash, err := fileHash(name)
+		if err != nil || hex.EncodeToString(hash) != want {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package crowdin
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCrowdin_DownloadTranslations_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	lastBuild := "2017-06-06T12:44:56+0000"
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"finished","progress":100,"last_build":"` + lastBuild + `"}`))
+	})
+
+	downloads := 0
+	mux.HandleFunc("/project-name/download/all.zip", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.Write([]byte("zip"))
+	})
+
+	crowdin.SetCache(NewMemoryCache())
+
+	local := filepath.Join(t.TempDir(), "all.zip")
+	download := func() {
+		t.Helper()
+		if err := crowdin.DownloadTranslations(&DownloadOptions{Package: "all", LocalPath: local}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	download()
+	download()
+	if downloads != 1 {
+		t.Errorf("Expected %v, got %v", 1, downloads)
+	}
+
+	os.WriteFile(local, []byte("edited"), 0o644)
+	download()
+	if downloads != 2 {
+		t.Errorf("Expected %v, got %v", 2, downloads)
+	}
+
+	lastBuild = "2017-06-07T10:00:00+0000"
+	download()
+	download()
+	if downloads != 3 {
+		t.Errorf("Expected %v, got %v", 3, downloads)
+	}
+
+	other := filepath.Join(t.TempDir(), "all.zip")
+	if err := crowdin.DownloadTranslations(&DownloadOptions{Package: "all", LocalPath: other}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if downloads != 4 {
+		t.Errorf("Expected %v, got %v", 4, downloads)
+	}
+}
+
+func TestCrowdin_DownloadAndExtract_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"finished","progress":100,"last_build":"2017-06-06T12:44:56+0000"}`))
+	})
+	serveZip(t, zipEntry{name: "ru/menu.csv", content: "play,Играть\n"})
+
+	crowdin.SetCache(NewMemoryCache())
+	options := &ExtractOptions{Package: "all", LocalPath: t.TempDir()}
+
+	result, err := crowdin.DownloadAndExtract(options)
+	if err != nil || result.Cached || len(result.Changed()) != 1 {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+
+	result, err = crowdin.DownloadAndExtract(options)
+	if err != nil || !result.Cached {
+		t.Errorf("Expected cached result, got %+v, %v", result, err)
+	}
+
+	// a new mapping writes other files, even if nothing was built
+	options.Pattern = "%two_letters_code%/%original_file_name%"
+	options.LanguagesMapping = map[string]map[string]string{"two_letters_code": {"ru": "russian"}}
+
+	result, err = crowdin.DownloadAndExtract(options)
+	if err != nil || result.Cached {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(options.LocalPath, "russian", "menu.csv")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	options.LanguagesMapping["two_letters_code"]["ru"] = "ru-mapped"
+
+	result, err = crowdin.DownloadAndExtract(options)
+	if err != nil || result.Cached {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(options.LocalPath, "ru-mapped", "menu.csv")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	cache, err := NewFileCache(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	entry := CacheEntry{
+		LastBuild: time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC),
+		Files:     map[string]string{"all.zip": "abc"},
+	}
+	if err := cache.Put("project/download", entry); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	cache, err = NewFileCache(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got, ok := cache.Get("project/download")
+	if !ok || !got.LastBuild.Equal(entry.LastBuild) || got.Files["all.zip"] != "abc" {
+		t.Errorf("Expected %v, got %v", entry, got)
+	}
+	if _, ok := cache.Get("project/other"); ok {
+		t.Errorf("Expected no entry")
+	}
+}
@@ -0,0 +1,76 @@
+package crowdin
+
+import (
+	"context"
+	"io"
+)
+
+// Client is the API of Crowdin, implemented by *Crowdin.
+// Depend on it instead of *Crowdin to replace the API in tests, e.g. with crowdintest.Mock.
+type Client interface {
+	// Files
+	AddFile(options *AddFileOptions) (*AddFileResult, error)
+	AddFileContext(ctx context.Context, options *AddFileOptions) (*AddFileResult, error)
+	UpdateFile(options *UpdateFileOptions) (*UpdateFileResult, error)
+	UpdateFileContext(ctx context.Context, options *UpdateFileOptions) (*UpdateFileResult, error)
+	DeleteFile(fileName string) (*GeneralResult, error)
+	DeleteFileContext(ctx context.Context, fileName string) (*GeneralResult, error)
+	AddOrUpdateFile(options *AddOrUpdateFileOptions) (*AddOrUpdateFileResult, error)
+	AddOrUpdateFileContext(ctx context.Context, options *AddOrUpdateFileOptions) (*AddOrUpdateFileResult, error)
+	Sync(options *SyncOptions) (*SyncPlan, error)
+	SyncContext(ctx context.Context, options *SyncOptions) (*SyncPlan, error)
+	SyncConfig(config *ResolvedConfig, options *ConfigSyncOptions) (*SyncPlan, error)
+	SyncConfigContext(ctx context.Context, config *ResolvedConfig, options *ConfigSyncOptions) (*SyncPlan, error)
+
+	// Translations
+	UploadTranslations(options *UploadTranslationsOptions) (*UploadTranslationResult, error)
+	UploadTranslationsContext(ctx context.Context, options *UploadTranslationsOptions) (*UploadTranslationResult, error)
+	GetTranslationsStatus() ([]TranslationStatus, error)
+	GetTranslationsStatusContext(ctx context.Context) ([]TranslationStatus, error)
+	GetLanguageStatus(languageCode string) (*LanguageStatus, error)
+	GetLanguageStatusContext(ctx context.Context, languageCode string) (*LanguageStatus, error)
+
+	// Export
+	ExportTranslations() (*ExportTranslationsResult, error)
+	ExportTranslationsContext(ctx context.Context) (*ExportTranslationsResult, error)
+	GetExportStatus() (*ExportStatus, error)
+	GetExportStatusContext(ctx context.Context) (*ExportStatus, error)
+	ExportAndWait(options *ExportWaitOptions) (*ExportResult, error)
+	ExportAndWaitContext(ctx context.Context, options *ExportWaitOptions) (*ExportResult, error)
+	DownloadTranslations(options *DownloadOptions) error
+	DownloadTranslationsContext(ctx context.Context, options *DownloadOptions) error
+	DownloadTranslationsTo(w io.Writer, options *DownloadOptions) error
+	DownloadTranslationsToContext(ctx context.Context, w io.Writer, options *DownloadOptions) error
+	DownloadAndExtract(options *ExtractOptions) (*ExtractResult, error)
+	DownloadAndExtractContext(ctx context.Context, options *ExtractOptions) (*ExtractResult, error)
+	DownloadConfig(config *ResolvedConfig, pkg string) (*ExtractResult, error)
+	DownloadConfigContext(ctx context.Context, config *ResolvedConfig, pkg string) (*ExtractResult, error)
+	ExportFile(options *ExportFileOptions) error
+	ExportFileContext(ctx context.Context, options *ExportFileOptions) error
+	ExportFileTo(w io.Writer, options *ExportFileOptions) error
+	ExportFileToContext(ctx context.Context, w io.Writer, options *ExportFileOptions) error
+
+	// Project
+	GetProjectDetails() (*ProjectInfo, error)
+	GetProjectDetailsContext(ctx context.Context) (*ProjectInfo, error)
+	EditProject(options *EditProjectOptions) (*ManageProjectResult, error)
+	EditProjectContext(ctx context.Context, options *EditProjectOptions) (*ManageProjectResult, error)
+	DeleteProject() (*DeleteProjectResult, error)
+	DeleteProjectContext(ctx context.Context) (*DeleteProjectResult, error)
+
+	// Directories
+	AddDirectory(directoryName string) (*GeneralResult, error)
+	AddDirectoryContext(ctx context.Context, directoryName string) (*GeneralResult, error)
+	ChangeDirectory(options *ChangeDirectoryOptions) (*GeneralResult, error)
+	ChangeDirectoryContext(ctx context.Context, options *ChangeDirectoryOptions) (*GeneralResult, error)
+	DeleteDirectory(directoryName string) (*GeneralResult, error)
+	DeleteDirectoryContext(ctx context.Context, directoryName string) (*GeneralResult, error)
+
+	// Account
+	GetAccountProjects(accountKey, loginUsername string) (*AccountDetails, error)
+	GetAccountProjectsContext(ctx context.Context, accountKey, loginUsername string) (*AccountDetails, error)
+	CreateProject(accountKey, loginUsername string, options *CreateProjectOptions) (*ManageProjectResult, error)
+	CreateProjectContext(ctx context.Context, accountKey, loginUsername string, options *CreateProjectOptions) (*ManageProjectResult, error)
+}
+
+var _ Client = (*Crowdin)(nil)
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+type runFunc = func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error)
+
+var commands = map[string]*command{
+	"upload-sources": {
+		usage: "[flags] [name=path ...]",
+		help:  "Add new and update existing source files, of crowdin.yml by default, or mirror a directory with -dir",
+		setup: uploadSources,
+	},
+	"upload-translations": {
+		usage: "-language code [flags] [name=path ...]",
+		help:  "Upload translations of source files to one language",
+		setup: uploadTranslations,
+	},
+	"download": {
+		usage: "[flags]",
+		help:  "Download translations to the paths of crowdin.yml or -out, or save the zip with -zip",
+		setup: download,
+	},
+	"export": {
+		usage: "[flags]",
+		help:  "Build the package of translations",
+		setup: export,
+	},
+	"status": {
+		usage: "[flags]",
+		help:  "Show translation and proofreading progress of every language",
+		setup: status,
+	},
+	"language-status": {
+		usage: "[flags] language",
+		help:  "Show translation progress of every file to the language",
+		setup: languageStatus,
+	},
+	"info": {
+		usage: "[flags]",
+		help:  "Show details and files of the project",
+		setup: info,
+	},
+	"delete-file": {
+		usage: "[flags] name",
+		help:  "Delete a source file with its translations",
+		setup: deleteFile,
+	},
+	"add-directory": {
+		usage: "[flags] name",
+		help:  "Create a directory",
+		setup: addDirectory,
+	},
+	"change-directory": {
+		usage: "[flags] name",
+		help:  "Rename a directory or change its title",
+		setup: changeDirectory,
+	},
+	"delete-directory": {
+		usage: "[flags] name",
+		help:  "Delete a directory with its files",
+		setup: deleteDirectory,
+	},
+	"projects": {
+		usage:   "[flags]",
+		help:    "List the projects of the account",
+		account: true,
+		setup:   projects,
+	},
+	"create-project": {
+		usage:   "-name name -identifier id -source-language code [flags]",
+		help:    "Create a project in the account",
+		account: true,
+		setup:   createProject,
+	},
+	"edit-project": {
+		usage: "[flags]",
+		help:  "Change name, languages or join policy of the project",
+		setup: editProject,
+	},
+	"delete-project": {
+		usage: "-yes [flags]",
+		help:  "Delete the project with all its translations",
+		setup: deleteProject,
+	},
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// message is a result of commands that report nothing but a line of text.
+type message string
+
+// filePairs parses "name=path" arguments to files by name in the project.
+// Arguments without "=" are both the name and the local path.
+func filePairs(args []string) map[string]string {
+	files := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, path, ok := strings.Cut(arg, "=")
+		if !ok {
+			path = arg
+		}
+		files[name] = path
+	}
+	return files
+}
+
+// configTranslations returns the existing translations of the sources of crowdin.yml to the language, by file name.
+func configTranslations(config *crowdin.ResolvedConfig, language string) map[string]string {
+	files := make(map[string]string)
+	if language == "" {
+		return files
+	}
+	for _, file := range config.Files {
+		local := file.TranslationPath(language)
+		if _, err := os.Stat(local); err == nil {
+			files[file.Name] = local
+		}
+	}
+	return files
+}
+
+// singleArg returns the only argument of the command.
+func singleArg(args []string, what string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%w: expected %v", errUsage, what)
+	}
+	return args[0], nil
+}
+
+func uploadSources(fs *flag.FlagSet) runFunc {
+
+	var include, exclude stringList
+	dir := fs.String("dir", "", "local directory to mirror to the project")
+	root := fs.String("root", "", "directory of the project the files of -dir are mirrored to")
+	fs.Var(&include, "include", "glob of the files of -dir to upload, can be repeated")
+	fs.Var(&exclude, "exclude", "glob of the files of -dir to skip, can be repeated")
+	del := fs.Bool("delete", false, "delete remote files that don't exist in -dir")
+	modifiedOnly := fs.Bool("modified-only", false, "update only the existing files modified after their last update in Crowdin")
+	dryRun := fs.Bool("dry-run", false, "only print the changes of -dir")
+	fileType := fs.String("type", "", "type of the new files, e.g. csv")
+	scheme := fs.String("scheme", "", "columns of CSV files, e.g. identifier,source_phrase,context")
+	header := fs.Bool("header", false, "first line of CSV files contains headers")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+
+		if *dir != "" {
+			if len(args) > 0 {
+				return nil, fmt.Errorf("%w: files can't be set with -dir", errUsage)
+			}
+			return api.SyncContext(ctx, &crowdin.SyncOptions{
+				LocalPath:               *dir,
+				Root:                    *root,
+				Include:                 include,
+				Exclude:                 exclude,
+				Delete:                  *del,
+				ModifiedOnly:            *modifiedOnly,
+				DryRun:                  *dryRun,
+				Type:                    crowdin.FileType(*fileType),
+				Scheme:                  crowdin.Scheme(*scheme),
+				FirstLineContainsHeader: *header,
+			})
+		}
+
+		if len(args) == 0 {
+			sources, err := cfg.sources()
+			if err != nil {
+				return nil, err
+			}
+			if sources == nil {
+				return nil, fmt.Errorf("%w: expected files, -dir or crowdin.yml", errUsage)
+			}
+			if *del {
+				return nil, fmt.Errorf("%w: -delete can't be used with crowdin.yml", errUsage)
+			}
+			return api.SyncConfigContext(ctx, sources, &crowdin.ConfigSyncOptions{ModifiedOnly: *modifiedOnly, DryRun: *dryRun})
+		}
+
+		return api.AddOrUpdateFileContext(ctx, &crowdin.AddOrUpdateFileOptions{
+			Type:                    crowdin.FileType(*fileType),
+			Scheme:                  crowdin.Scheme(*scheme),
+			FirstLineContainsHeader: *header,
+			Files:                   filePairs(args),
+		})
+	}
+}
+
+func uploadTranslations(fs *flag.FlagSet) runFunc {
+
+	language := fs.String("language", "", "target language")
+	duplicates := fs.Bool("import-duplicates", false, "add translations that are the same as the existing ones")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+
+		files := filePairs(args)
+		if len(args) == 0 {
+			sources, err := cfg.sources()
+			if err != nil {
+				return nil, err
+			}
+			if sources != nil {
+				files = configTranslations(sources, *language)
+			}
+		}
+
+		if len(files) == 0 {
+			return nil, fmt.Errorf("%w: expected files or crowdin.yml with translations", errUsage)
+		}
+
+		options := &crowdin.UploadTranslationsOptions{
+			Language:         *language,
+			Files:            files,
+			ImportDuplicates: "0",
+		}
+		if *duplicates {
+			options.ImportDuplicates = "1"
+		}
+
+		return api.UploadTranslationsContext(ctx, options)
+	}
+}
+
+func download(fs *flag.FlagSet) runFunc {
+
+	pkg := fs.String("package", "all", "language code or all")
+	out := fs.String("out", "", "directory the translations are extracted to")
+	pattern := fs.String("pattern", "", "path of the extracted files, e.g. values-%android_code%/%file_name%.xml")
+	zipPath := fs.String("zip", "", "save the zip to the path instead of extracting it")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+
+		if *zipPath != "" {
+			err := api.DownloadTranslationsContext(ctx, &crowdin.DownloadOptions{Package: *pkg, LocalPath: *zipPath})
+			if err != nil {
+				return nil, err
+			}
+			return message("Downloaded " + *zipPath), nil
+		}
+
+		if *out == "" {
+			sources, err := cfg.sources()
+			if err != nil {
+				return nil, err
+			}
+			if sources == nil {
+				return nil, fmt.Errorf("%w: expected -out, -zip or crowdin.yml", errUsage)
+			}
+			return api.DownloadConfigContext(ctx, sources, *pkg)
+		}
+
+		return api.DownloadAndExtractContext(ctx, &crowdin.ExtractOptions{
+			Package:   *pkg,
+			LocalPath: *out,
+			Pattern:   *pattern,
+		})
+	}
+}
+
+func export(fs *flag.FlagSet) runFunc {
+
+	wait := fs.Bool("wait", false, "wait until the build is finished")
+	timeout := fs.Duration("timeout", 0, "max time to wait for the build")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+
+		if !*wait {
+			return api.ExportTranslationsContext(ctx)
+		}
+
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+
+		return api.ExportAndWaitContext(ctx, nil)
+	}
+}
+
+func status(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		return api.GetTranslationsStatusContext(ctx)
+	}
+}
+
+func languageStatus(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		language, err := singleArg(args, "language")
+		if err != nil {
+			return nil, err
+		}
+		return api.GetLanguageStatusContext(ctx, language)
+	}
+}
+
+func info(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		return api.GetProjectDetailsContext(ctx)
+	}
+}
+
+func deleteFile(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		name, err := singleArg(args, "file name")
+		if err != nil {
+			return nil, err
+		}
+		return api.DeleteFileContext(ctx, name)
+	}
+}
+
+func addDirectory(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		name, err := singleArg(args, "directory name")
+		if err != nil {
+			return nil, err
+		}
+		return api.AddDirectoryContext(ctx, name)
+	}
+}
+
+func changeDirectory(fs *flag.FlagSet) runFunc {
+
+	newName := fs.String("new-name", "", "new name of the directory, without path")
+	title := fs.String("title", "", "title of the directory in Crowdin UI")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		name, err := singleArg(args, "directory name")
+		if err != nil {
+			return nil, err
+		}
+		return api.ChangeDirectoryContext(ctx, &crowdin.ChangeDirectoryOptions{Name: name, NewName: *newName, Title: *title})
+	}
+}
+
+func deleteDirectory(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		name, err := singleArg(args, "directory name")
+		if err != nil {
+			return nil, err
+		}
+		return api.DeleteDirectoryContext(ctx, name)
+	}
+}
+
+func projects(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		return api.GetAccountProjectsContext(ctx, cfg.AccountKey, cfg.Login)
+	}
+}
+
+func createProject(fs *flag.FlagSet) runFunc {
+
+	name := fs.String("name", "", "project name")
+	identifier := fs.String("identifier", "", "unique project identifier")
+	sourceLanguage := fs.String("source-language", "", "language of the source files")
+	languages := fs.String("languages", "", "comma separated target languages")
+	joinPolicy := fs.String("join-policy", "", "open or private")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		return api.CreateProjectContext(ctx, cfg.AccountKey, cfg.Login, &crowdin.CreateProjectOptions{
+			Name:           *name,
+			Identifier:     *identifier,
+			SourceLanguage: *sourceLanguage,
+			Languages:      splitList(*languages),
+			JoinPolicy:     crowdin.JoinPolicy(*joinPolicy),
+		})
+	}
+}
+
+func editProject(fs *flag.FlagSet) runFunc {
+
+	name := fs.String("name", "", "new project name")
+	languages := fs.String("languages", "", "comma separated target languages")
+	joinPolicy := fs.String("join-policy", "", "open or private")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		return api.EditProjectContext(ctx, &crowdin.EditProjectOptions{
+			Name:       *name,
+			Languages:  splitList(*languages),
+			JoinPolicy: crowdin.JoinPolicy(*joinPolicy),
+		})
+	}
+}
+
+func deleteProject(fs *flag.FlagSet) runFunc {
+
+	yes := fs.Bool("yes", false, "confirm the deletion")
+
+	return func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error) {
+		if !*yes {
+			return nil, fmt.Errorf("%w: deletion of the project must be confirmed with -yes", errUsage)
+		}
+		return api.DeleteProjectContext(ctx)
+	}
+}
+
+// splitList splits comma separated values, empty string is no values.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
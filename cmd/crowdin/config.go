@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+// Environment variables with the settings, used when the flags aren't set.
+const (
+	envProject        = "CROWDIN_PROJECT"
+	envAPIKey         = "CROWDIN_API_KEY"
+	envAccountKey     = "CROWDIN_ACCOUNT_KEY"
+	envLogin          = "CROWDIN_LOGIN"
+	envBaseURL        = "CROWDIN_BASE_URL"
+	envAccountBaseURL = "CROWDIN_ACCOUNT_BASE_URL"
+	envConfig         = "CROWDIN_CONFIG"
+)
+
+// config is the settings shared by all the commands. Flags take precedence over
+// the environment, and the environment over the config file.
+type config struct {
+	Project        string `json:"project"`
+	APIKey         string `json:"api_key"`
+	AccountKey     string `json:"account_key"`
+	Login          string `json:"login"`
+	BaseURL        string `json:"base_url"`
+	AccountBaseURL string `json:"account_base_url"`
+
+	path string
+	json bool
+
+	// crowdin.yml and the environment of its *_env settings, nil if the config file is json.
+	// Its sources are matched only by the commands that use them, see sources.
+	yml    *crowdin.ConfigFile
+	getenv func(string) string
+}
+
+// defaultConfig is the config file of the official tooling, used when no config file is set.
+const defaultConfig = "crowdin.yml"
+
+// register adds the common flags to the flag set of a command.
+func (c *config) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.path, "config", "", "path of a json config file or crowdin.yml, $"+envConfig)
+	fs.StringVar(&c.Project, "project", "", "project identifier, $"+envProject)
+	fs.StringVar(&c.APIKey, "api-key", "", "API key of the project, $"+envAPIKey)
+	fs.StringVar(&c.AccountKey, "account-key", "", "account API key, $"+envAccountKey)
+	fs.StringVar(&c.Login, "login", "", "account login, $"+envLogin)
+	fs.StringVar(&c.BaseURL, "base-url", "", "base URL of project API, $"+envBaseURL)
+	fs.StringVar(&c.AccountBaseURL, "account-base-url", "", "base URL of account API, $"+envAccountBaseURL)
+	fs.BoolVar(&c.json, "json", false, "print the results as json")
+}
+
+// resolve fills the settings that weren't set with flags from the environment and the config file.
+// Without a config file, crowdin.yml of the working directory is used if it exists.
+func (c *config) resolve(getenv func(string) string) error {
+
+	fromEnv := func(value *string, name string) {
+		if *value == "" {
+			*value = getenv(name)
+		}
+	}
+
+	fromEnv(&c.path, envConfig)
+	fromEnv(&c.Project, envProject)
+	fromEnv(&c.APIKey, envAPIKey)
+	fromEnv(&c.AccountKey, envAccountKey)
+	fromEnv(&c.Login, envLogin)
+	fromEnv(&c.BaseURL, envBaseURL)
+	fromEnv(&c.AccountBaseURL, envAccountBaseURL)
+
+	if c.path == "" {
+		if _, err := os.Stat(defaultConfig); err != nil {
+			return nil
+		}
+		c.path = defaultConfig
+	}
+
+	var file config
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+
+	switch filepath.Ext(c.path) {
+	case ".yml", ".yaml":
+		if c.yml, err = crowdin.ParseConfig(data); err != nil {
+			return fmt.Errorf("invalid config file %v: %w", c.path, err)
+		}
+		c.getenv = getenv
+		file.Project, file.APIKey = c.yml.Credentials(getenv)
+
+	default:
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("invalid config file %v: %w", c.path, err)
+		}
+	}
+
+	fromFile := func(value *string, fileValue string) {
+		if *value == "" {
+			*value = fileValue
+		}
+	}
+
+	fromFile(&c.Project, file.Project)
+	fromFile(&c.APIKey, file.APIKey)
+	fromFile(&c.AccountKey, file.AccountKey)
+	fromFile(&c.Login, file.Login)
+	fromFile(&c.BaseURL, file.BaseURL)
+	fromFile(&c.AccountBaseURL, file.AccountBaseURL)
+
+	return nil
+}
+
+// sources matches the sources of crowdin.yml. It returns nil if the config file is json or has no files.
+func (c *config) sources() (*crowdin.ResolvedConfig, error) {
+	if c.yml == nil || len(c.yml.Files) == 0 {
+		return nil, nil
+	}
+	sources, err := c.yml.Resolve(filepath.Dir(c.path), c.getenv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %v: %w", c.path, err)
+	}
+	return sources, nil
+}
+
+var errNoProject = errors.New("project and API key are required, set -project and -api-key, $" + envProject + " and $" + envAPIKey + " or a config file")
+
+var errNoAccount = errors.New("account key and login are required, set -account-key and -login, $" + envAccountKey + " and $" + envLogin + " or a config file")
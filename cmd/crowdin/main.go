@@ -0,0 +1,186 @@
+// Command crowdin runs the operations of Crowdin API from the command line.
+//
+// Usage:
+//
+//	crowdin <command> [flags] [arguments]
+//
+// Credentials are read from flags, the environment ($CROWDIN_PROJECT, $CROWDIN_API_KEY,
+// $CROWDIN_ACCOUNT_KEY, $CROWDIN_LOGIN) or a json config file set with -config or $CROWDIN_CONFIG:
+//
+//	{"project": "my-game", "api_key": "...", "account_key": "...", "login": "..."}
+//
+// The config file may also be crowdin.yml of the official tooling, which is used by default
+// when it's in the working directory. Its sources are then uploaded by upload-sources,
+// and its translations uploaded by upload-translations and downloaded by download.
+//
+// Results are printed in a human readable form, or as json with -json. Exit codes:
+//
+//	0  success
+//	1  the call failed
+//	2  invalid usage, options or credentials
+//	3  some of the files of a batched call failed
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+// Exit codes.
+const (
+	exitOK      = 0
+	exitFailed  = 1
+	exitUsage   = 2
+	exitPartial = 3
+)
+
+// errUsage is returned by commands for invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// command is a subcommand of the tool.
+type command struct {
+	// Synopsis of the arguments, e.g. "[flags] name".
+	usage string
+
+	// One line description.
+	help string
+
+	// Account commands need the account key and login instead of the project key.
+	account bool
+
+	// setup registers the flags of the command and returns the function that runs it.
+	// The result of run is printed, see printResult.
+	setup func(fs *flag.FlagSet) func(ctx context.Context, api *crowdin.Crowdin, cfg *config, args []string) (interface{}, error)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command of the arguments and returns the exit code.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "crowdin: unknown command %q\n\n", name)
+		usage(stderr)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("crowdin "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: crowdin %v %v\n\n%v\n\nFlags:\n", name, cmd.usage, cmd.help)
+		fs.PrintDefaults()
+	}
+
+	cfg := &config{}
+	cfg.register(fs)
+	runCommand := cmd.setup(fs)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if err := cfg.resolve(getenv); err != nil {
+		printError(stderr, err)
+		return exitUsage
+	}
+
+	if cmd.account && (cfg.AccountKey == "" || cfg.Login == "") {
+		printError(stderr, errNoAccount)
+		return exitUsage
+	}
+	if !cmd.account && (cfg.Project == "" || cfg.APIKey == "") {
+		printError(stderr, errNoProject)
+		return exitUsage
+	}
+
+	var options []crowdin.Option
+	if cfg.BaseURL != "" {
+		options = append(options, crowdin.WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.AccountBaseURL != "" {
+		options = append(options, crowdin.WithAccountBaseURL(cfg.AccountBaseURL))
+	}
+	api := crowdin.NewWithOptions(cfg.APIKey, cfg.Project, options...)
+	api.SetRetryPolicy(&crowdin.DefaultRetryPolicy)
+
+	result, err := runCommand(ctx, api, cfg, fs.Args())
+
+	if printErr := printResult(stdout, result, cfg.json); printErr != nil && err == nil {
+		err = printErr
+	}
+
+	if err != nil {
+		printError(stderr, err)
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+		}
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+// printError prints the error of a command. The "crowdin: " prefixes of the errors of the library
+// are dropped, including the wrapped ones, since the line already starts with it.
+func printError(w io.Writer, err error) {
+	message := strings.TrimPrefix(err.Error(), "crowdin: ")
+	fmt.Fprintf(w, "crowdin: %v\n", strings.ReplaceAll(message, ": crowdin: ", ": "))
+}
+
+// exitCode returns the exit code of the error of a command.
+func exitCode(err error) int {
+
+	var batchErr *crowdin.BatchError
+	switch {
+	case errors.Is(err, errUsage), errors.Is(err, crowdin.ErrInvalidOptions):
+		return exitUsage
+	case errors.As(err, &batchErr):
+		return exitPartial
+	default:
+		return exitFailed
+	}
+}
+
+func usage(w io.Writer) {
+
+	fmt.Fprintf(w, "Usage: crowdin <command> [flags] [arguments]\n\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-22v %v\n", name, commands[name].help)
+	}
+
+	fmt.Fprintf(w, "\nRun 'crowdin <command> -h' for the flags of the command.\n")
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	crowdin "github.com/medisafe/go-crowdin"
+	"github.com/medisafe/go-crowdin/crowdintest"
+)
+
+// env returns the environment with the credentials of the server.
+func env(server *crowdintest.Server) func(string) string {
+	values := map[string]string{
+		envProject:        server.Project,
+		envAPIKey:         server.Key,
+		envAccountKey:     server.AccountKey,
+		envLogin:          "test-login",
+		envBaseURL:        server.URL + "/api/project/",
+		envAccountBaseURL: server.URL + "/api/account/",
+	}
+	return func(name string) string {
+		return values[name]
+	}
+}
+
+func runArgs(getenv func(string) string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, getenv, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_usage(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	noEnv := func(string) string { return "" }
+
+	tests := []struct {
+		getenv func(string) string
+		args   []string
+		code   int
+	}{
+		{noEnv, nil, exitUsage},
+		{noEnv, []string{"help"}, exitOK},
+		{noEnv, []string{"unknown"}, exitUsage},
+		{noEnv, []string{"status"}, exitUsage},
+		{noEnv, []string{"projects", "-project", server.Project, "-api-key", server.Key}, exitUsage},
+		{env(server), []string{"status", "-unknown"}, exitUsage},
+		{env(server), []string{"delete-directory"}, exitUsage},
+		{env(server), []string{"delete-project"}, exitUsage},
+		{env(server), []string{"upload-sources"}, exitUsage},
+		{env(server), []string{"upload-sources", "-type", "unknown", "menu.csv"}, exitUsage},
+	}
+
+	for _, test := range tests {
+		if code, _, _ := runArgs(test.getenv, test.args...); code != test.code {
+			t.Errorf("%v: Expected %v, got %v", test.args, test.code, code)
+		}
+	}
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("Expected no requests, got %v", len(requests))
+	}
+}
+
+func TestRun_config(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "crowdin.json")
+	os.WriteFile(path, []byte(fmt.Sprintf(`{"project": %q, "api_key": "wrong-key", "base_url": %q}`,
+		server.Project, server.URL+"/api/project/")), 0644)
+
+	getenv := func(name string) string {
+		if name == envAPIKey {
+			return server.Key
+		}
+		return ""
+	}
+
+	// the key of the environment takes precedence over the file
+	if code, _, stderr := runArgs(getenv, "status", "-config", path); code != exitOK {
+		t.Errorf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	// and the flag over the environment
+	if code, _, _ := runArgs(getenv, "status", "-config", path, "-api-key", "wrong-key"); code != exitFailed {
+		t.Errorf("Expected %v, got %v", exitFailed, code)
+	}
+}
+
+func TestRun_uploadAndDownload(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "ui"), 0755)
+	os.WriteFile(filepath.Join(dir, "ui", "menu.csv"), []byte("play,Play\n"), 0644)
+
+	code, stdout, stderr := runArgs(env(server), "upload-sources", "-dir", dir, "-dry-run")
+	if code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+	if !strings.Contains(stdout, "ui/menu.csv") {
+		t.Errorf("Expected the plan, got %q", stdout)
+	}
+	if files := server.Files(); len(files) != 0 {
+		t.Errorf("Expected no files, got %v", files)
+	}
+
+	if code, _, stderr := runArgs(env(server), "upload-sources", "-dir", dir); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+	if files := server.Files(); len(files) != 1 || files[0] != "ui/menu.csv" {
+		t.Errorf("Expected %v, got %v", []string{"ui/menu.csv"}, files)
+	}
+
+	translation := filepath.Join(dir, "menu.ru.csv")
+	os.WriteFile(translation, []byte("play,Играть\n"), 0644)
+
+	if code, _, stderr := runArgs(env(server), "upload-translations", "-language", "ru", "ui/menu.csv="+translation); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	if code, _, stderr := runArgs(env(server), "export", "-wait"); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	out := t.TempDir()
+	code, stdout, stderr = runArgs(env(server), "download", "-package", "ru", "-out", out, "-pattern", "%locale%/%original_file_name%", "-json")
+	if code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	var result crowdin.ExtractResult
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("Expected json, got %q", stdout)
+	}
+	if len(result.Files) != 1 || !result.Files[0].Changed {
+		t.Errorf("Unexpected files %+v", result.Files)
+	}
+
+	content, err := os.ReadFile(filepath.Join(out, "ru-RU", "menu.csv"))
+	if err != nil || string(content) != "play,Играть\n" {
+		t.Errorf("Expected %q, got %q (%v)", "play,Играть\n", content, err)
+	}
+}
+
+func TestRun_json(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	code, stdout, stderr := runArgs(env(server), "status", "-json")
+	if code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	var statuses []crowdin.TranslationStatus
+	if err := json.Unmarshal([]byte(stdout), &statuses); err != nil {
+		t.Fatalf("Expected json, got %q", stdout)
+	}
+	if len(statuses) != len(server.Languages) {
+		t.Errorf("Expected %v, got %v", len(server.Languages), len(statuses))
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: expected files", errUsage), exitUsage},
+		{fmt.Errorf("wrapped: %w", crowdin.ErrInvalidOptions), exitUsage},
+		{&crowdin.BatchError{Failures: []crowdin.FileFailure{{Name: "a.csv", Err: crowdin.ErrFileNotFound}}}, exitPartial},
+		{crowdin.ErrFileNotFound, exitFailed},
+	}
+
+	for _, test := range tests {
+		if code := exitCode(test.err); code != test.code {
+			t.Errorf("%v: Expected %v, got %v", test.err, test.code, code)
+		}
+	}
+}
+
+func TestRun_crowdinYML(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "locale", "en"), 0755)
+	os.MkdirAll(filepath.Join(dir, "locale", "ru"), 0755)
+	os.WriteFile(filepath.Join(dir, "locale", "en", "menu.csv"), []byte("play,Play\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "locale", "ru", "menu.csv"), []byte("play,Играть\n"), 0644)
+
+	path := filepath.Join(dir, "crowdin.yml")
+	os.WriteFile(path, []byte(fmt.Sprintf(`
+project_identifier: %v
+api_key_env: GAME_CROWDIN_KEY
+files:
+  - source: /locale/en/*.csv
+    translation: /locale/%%two_letters_code%%/%%original_file_name%%
+`, server.Project)), 0644)
+
+	getenv := func(name string) string {
+		switch name {
+		case "GAME_CROWDIN_KEY":
+			return server.Key
+		case envBaseURL:
+			return server.URL + "/api/project/"
+		}
+		return ""
+	}
+
+	if code, _, stderr := runArgs(getenv, "upload-sources", "-config", path); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+	if files := server.Files(); len(files) != 1 || files[0] != "menu.csv" {
+		t.Errorf("Expected %v, got %v", []string{"menu.csv"}, files)
+	}
+
+	if code, _, _ := runArgs(getenv, "upload-sources", "-config", path, "-delete"); code != exitUsage {
+		t.Errorf("Expected %v, got %v", exitUsage, code)
+	}
+
+	if code, _, stderr := runArgs(getenv, "upload-translations", "-config", path, "-language", "ru"); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+	if content, _ := server.Translation("ru", "menu.csv"); string(content) != "play,Играть\n" {
+		t.Errorf("Expected %q, got %q", "play,Играть\n", content)
+	}
+
+	os.Remove(filepath.Join(dir, "locale", "ru", "menu.csv"))
+
+	if code, _, stderr := runArgs(getenv, "download", "-config", path, "-package", "ru"); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "locale", "ru", "menu.csv"))
+	if err != nil || string(content) != "play,Играть\n" {
+		t.Errorf("Expected %q, got %q (%v)", "play,Играть\n", content, err)
+	}
+}
+
+func TestRun_crowdinYMLCredentials(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "crowdin.yml")
+	os.WriteFile(path, []byte(fmt.Sprintf("project_identifier: %v\napi_key_env: GAME_CROWDIN_KEY\n", server.Project)), 0644)
+
+	getenv := func(name string) string {
+		switch name {
+		case "GAME_CROWDIN_KEY":
+			return server.Key
+		case envBaseURL:
+			return server.URL + "/api/project/"
+		}
+		return ""
+	}
+
+	// the files are matched only by the commands that use them
+	if code, _, stderr := runArgs(getenv, "status", "-config", path); code != exitOK {
+		t.Fatalf("Expected %v, got %v: %v", exitOK, code, stderr)
+	}
+
+	code, _, stderr := runArgs(getenv, "upload-sources", "-config", path)
+	if code != exitUsage {
+		t.Errorf("Expected %v, got %v", exitUsage, code)
+	}
+	if !strings.HasPrefix(stderr, "crowdin: invalid usage: expected files, -dir or crowdin.yml\n") {
+		t.Errorf("Unexpected error %q", stderr)
+	}
+
+	os.WriteFile(path, []byte(fmt.Sprintf("project_identifier: %v\napi_key_env: GAME_CROWDIN_KEY\nfiles:\n  - source: /*.csv\n", server.Project)), 0644)
+
+	code, _, stderr = runArgs(getenv, "download", "-config", path)
+	if code != exitUsage {
+		t.Errorf("Expected %v, got %v", exitUsage, code)
+	}
+	if want := "crowdin: invalid config file " + path + ": invalid options: files[0]: translation can't be empty\n"; stderr != want {
+		t.Errorf("Expected %q, got %q", want, stderr)
+	}
+}
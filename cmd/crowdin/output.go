@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+// printResult prints the result of a command, as json or in a human readable form.
+// Nothing is printed for nil results, e.g. when the call failed.
+func printResult(w io.Writer, v interface{}, asJSON bool) error {
+
+	if isNil(v) {
+		return nil
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	switch result := v.(type) {
+
+	case message:
+		fmt.Fprintln(tw, result)
+
+	case *crowdin.SyncPlan:
+		if result.Empty() {
+			fmt.Fprintln(tw, "Nothing to sync")
+		}
+		fmt.Fprint(tw, result.String())
+
+	case *crowdin.AddOrUpdateFileResult:
+		for _, dir := range result.Directories {
+			fmt.Fprintf(tw, "added directory\t%v\n", dir)
+		}
+		if result.Added != nil {
+			printAdded(tw, result.Added)
+		}
+		if result.Updated != nil {
+			printUpdated(tw, result.Updated)
+		}
+
+	case *crowdin.UploadTranslationResult:
+		for _, file := range result.Stats.Files {
+			fmt.Fprintf(tw, "%v\t%v\n", file.Status, file.Name)
+		}
+		printFailures(tw, result.Failures)
+
+	case *crowdin.ExtractResult:
+		if result.Cached {
+			fmt.Fprintln(tw, "Nothing was built since the last download")
+		}
+		for _, file := range result.Changed() {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", file.Language, file.Name, file.LocalPath)
+		}
+		if !result.Cached {
+			fmt.Fprintf(tw, "%v files, %v changed\n", len(result.Files), len(result.Changed()))
+		}
+
+	case *crowdin.ExportTranslationsResult:
+		printBuild(tw, result.Success.Status, result.ThrottledUntil)
+
+	case *crowdin.ExportResult:
+		printBuild(tw, result.Status, result.ThrottledUntil)
+		if result.Export != nil && !result.Export.LastBuild.IsZero() {
+			fmt.Fprintf(tw, "last build\t%v\n", result.Export.LastBuild.Format(time.RFC3339))
+		}
+
+	case []crowdin.TranslationStatus:
+		fmt.Fprintln(tw, "CODE\tLANGUAGE\tTRANSLATED\tAPPROVED")
+		for _, status := range result {
+			fmt.Fprintf(tw, "%v\t%v\t%v%%\t%v%%\n", status.Code, status.Name, status.TranslatedProgress, status.ApprovedProgress)
+		}
+
+	case *crowdin.LanguageStatus:
+		fmt.Fprintln(tw, "FILE\tPHRASES\tTRANSLATED\tAPPROVED")
+		result.Files.Walk(func(path string, node *crowdin.FileNode) error {
+			if !node.IsDir() {
+				fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", path, node.Phrases, node.Translated, node.Approved)
+			}
+			return nil
+		})
+
+	case *crowdin.ProjectInfo:
+		details := result.Details
+		fmt.Fprintf(tw, "name\t%v\n", details.Name)
+		fmt.Fprintf(tw, "identifier\t%v\n", details.Identifier)
+		fmt.Fprintf(tw, "source language\t%v\n", details.SourceLanguage.Code)
+		fmt.Fprintf(tw, "strings\t%v\n", details.TotalStringsCount)
+		if !details.LastBuild.IsZero() {
+			fmt.Fprintf(tw, "last build\t%v\n", details.LastBuild.Format(time.RFC3339))
+		}
+		for _, path := range result.Files.Paths() {
+			fmt.Fprintf(tw, "file\t%v\n", path)
+		}
+
+	case *crowdin.AccountDetails:
+		fmt.Fprintln(tw, "IDENTIFIER\tNAME\tROLE")
+		for _, project := range result.Projects {
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", project.Identifier, project.Name, project.Role)
+		}
+
+	case *crowdin.ManageProjectResult:
+		fmt.Fprintf(tw, "url\t%v\n", result.Project.URL)
+		if result.Project.Key != "" {
+			fmt.Fprintf(tw, "key\t%v\n", result.Project.Key)
+		}
+
+	default:
+		fmt.Fprintln(tw, "OK")
+	}
+
+	return tw.Flush()
+}
+
+func printAdded(w io.Writer, result *crowdin.AddFileResult) {
+	for _, file := range result.Stats.Files {
+		fmt.Fprintf(w, "added\t%v\t%v strings\n", file.Name, file.Strings)
+	}
+	printFailures(w, result.Failures)
+}
+
+func printUpdated(w io.Writer, result *crowdin.UpdateFileResult) {
+	for _, name := range sortedNames(result.Files) {
+		fmt.Fprintf(w, "%v\t%v\n", result.Files[name], name)
+	}
+	printFailures(w, result.Failures)
+}
+
+func printFailures(w io.Writer, failures []crowdin.FileFailure) {
+	for _, failure := range failures {
+		fmt.Fprintf(w, "failed\t%v\t%v\n", failure.Name, failure.Err)
+	}
+}
+
+func printBuild(w io.Writer, status crowdin.BuildStatus, throttledUntil time.Time) {
+	fmt.Fprintf(w, "status\t%v\n", status)
+	if status == crowdin.ExportThrottled {
+		fmt.Fprintf(w, "next export\t%v\n", throttledUntil.Format(time.RFC3339))
+	}
+}
+
+// isNil reports whether v is nil, including nil pointers returned as interface{}.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+	return false
+}
+
+func sortedNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,467 @@
+package crowdin
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the content of crowdin.yml, the configuration file of the official Crowdin tooling.
+type ConfigFile struct {
+	// Project identifier, or the environment variable that holds it.
+	ProjectIdentifier    string `yaml:"project_identifier"`
+	ProjectIdentifierEnv string `yaml:"project_identifier_env"`
+
+	// API key of the project, or the environment variable that holds it.
+	APIKey    string `yaml:"api_key"`
+	APIKeyEnv string `yaml:"api_key_env"`
+
+	// Directory the paths of Files are relative to, relative to the directory of the config file.
+	BasePath string `yaml:"base_path"`
+
+	// Keep the local directories of the sources in the project. Otherwise the directories
+	// shared by all the sources are removed from their paths in the project.
+	PreserveHierarchy bool `yaml:"preserve_hierarchy"`
+
+	Files []FileGroup `yaml:"files"`
+}
+
+// FileGroup is an entry of files of crowdin.yml.
+type FileGroup struct {
+	// Glob of the source files relative to the base path, e.g. "/locale/en/**/*.csv".
+	Source string `yaml:"source"`
+
+	// Pattern of the translations relative to the base path, e.g. "/locale/%two_letters_code%/**/%original_file_name%".
+	// "**" is replaced with the directories matched by "**" of Source.
+	Translation string `yaml:"translation"`
+
+	// Globs of the files and directories to skip, relative to the base path.
+	Ignore []string `yaml:"ignore"`
+
+	// Pattern of the path of the sources in the project, e.g. "/ui/%file_name%.csv".
+	Dest string `yaml:"dest"`
+
+	// Type, scheme and header of the sources, see AddFileOptions.
+	Type                    FileType `yaml:"type"`
+	Scheme                  Scheme   `yaml:"scheme"`
+	FirstLineContainsHeader bool     `yaml:"first_line_contains_header"`
+
+	// Values of the placeholders of Translation by language, e.g. {"two_letters_code": {"zh-CN": "zh"}}.
+	LanguagesMapping map[string]map[string]string `yaml:"languages_mapping"`
+}
+
+// ResolvedConfig is a config file with the sources matched in the base path, see LoadConfig.
+type ResolvedConfig struct {
+	// Project identifier and API key, empty if they aren't set in the file or the environment.
+	Project string
+	APIKey  string
+
+	// Base path of the files.
+	BasePath string
+
+	// Matched source files, in the order of the groups and paths.
+	Files []ResolvedFile
+}
+
+// ResolvedFile is a source file matched by a group of the config file.
+type ResolvedFile struct {
+	// Path in the project, e.g. "ui/menu.csv".
+	Name string
+
+	// Path relative to the base path, e.g. "locale/en/ui/menu.csv".
+	Path string
+
+	// Path of the local file.
+	LocalPath string
+
+	// Translation pattern of the group, with "**" replaced.
+	Translation string
+
+	Type                    FileType
+	Scheme                  Scheme
+	FirstLineContainsHeader bool
+	LanguagesMapping        map[string]map[string]string
+
+	basePath string
+}
+
+// TranslationPath returns the local path of the translation of the file to the language.
+func (file *ResolvedFile) TranslationPath(language string) string {
+	return filepath.Join(file.basePath, filepath.FromSlash(file.translationName(language)))
+}
+
+// translationName returns the path of the translation relative to the base path.
+// Placeholders of the original path and name are expanded from the path in the project, like the official tooling does.
+func (file *ResolvedFile) translationName(language string) string {
+	return expandPattern(file.Translation, language, file.Name, file.LanguagesMapping)
+}
+
+// ParseConfig - Parse the content of crowdin.yml. Unknown settings are ignored.
+func ParseConfig(data []byte) (*ConfigFile, error) {
+
+	config := &ConfigFile{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("crowdin: invalid config: %w", err)
+	}
+
+	return config, nil
+}
+
+// LoadConfig - Read crowdin.yml at the path and resolve it, with the base path relative to its directory
+// and the *_env settings read from the environment.
+func LoadConfig(name string) (*ResolvedConfig, error) {
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", name, err)
+	}
+
+	return config.Resolve(filepath.Dir(name), os.Getenv)
+}
+
+// Validate - Check the groups of files before Resolve.
+func (config *ConfigFile) Validate() error {
+
+	if config == nil || len(config.Files) == 0 {
+		return invalid("files can't be empty")
+	}
+
+	for i, group := range config.Files {
+
+		if group.Source == "" {
+			return invalid("files[%v]: source can't be empty", i)
+		}
+		if strings.Count(group.Source, "**") > 1 {
+			return invalid("files[%v]: source %q can't have more than one **", i, group.Source)
+		}
+
+		if group.Translation == "" {
+			return invalid("files[%v]: translation can't be empty", i)
+		}
+		if err := validatePattern(group.Translation); err != nil {
+			return err
+		}
+		if !hasLanguagePlaceholder(group.Translation) {
+			return invalid("files[%v]: translation %q has no language placeholder", i, group.Translation)
+		}
+
+		if err := validatePattern(group.Dest); err != nil {
+			return err
+		}
+		if hasLanguagePlaceholder(group.Dest) {
+			return invalid("files[%v]: dest %q can't have a language placeholder", i, group.Dest)
+		}
+
+		if !group.Type.Valid() {
+			return invalid("files[%v]: unknown file type %q", i, group.Type)
+		}
+		if err := group.Scheme.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasLanguagePlaceholder reports whether the pattern has a placeholder of the language.
+func hasLanguagePlaceholder(pattern string) bool {
+
+	for _, placeholder := range placeholderPattern.FindAllString(pattern, -1) {
+		switch placeholder {
+		case PlaceholderTwoLettersCode, PlaceholderLocale, PlaceholderLocaleWithUnderscore,
+			PlaceholderAndroidCode, PlaceholderOSXCode, PlaceholderOSXLocale:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Credentials - Project identifier and API key of the file. Getenv reads the *_env settings,
+// which take precedence over the values of the file. The files aren't validated nor matched.
+func (config *ConfigFile) Credentials(getenv func(string) string) (project, apiKey string) {
+
+	project, apiKey = config.ProjectIdentifier, config.APIKey
+
+	if config.ProjectIdentifierEnv != "" {
+		if value := getenv(config.ProjectIdentifierEnv); value != "" {
+			project = value
+		}
+	}
+	if config.APIKeyEnv != "" {
+		if value := getenv(config.APIKeyEnv); value != "" {
+			apiKey = value
+		}
+	}
+
+	return project, apiKey
+}
+
+// Resolve - Match the sources of the groups in the base path, relative to dir. Getenv reads
+// the *_env settings, which take precedence over the values of the file. A file matched by several
+// groups belongs to the first one.
+func (config *ConfigFile) Resolve(dir string, getenv func(string) string) (*ResolvedConfig, error) {
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedConfig{
+		BasePath: filepath.Join(dir, filepath.FromSlash(config.BasePath)),
+	}
+	if filepath.IsAbs(config.BasePath) {
+		resolved.BasePath = filepath.Clean(config.BasePath)
+	}
+
+	resolved.Project, resolved.APIKey = config.Credentials(getenv)
+
+	groups := make([][]ResolvedFile, len(config.Files))
+
+	err := fs.WalkDir(os.DirFS(resolved.BasePath), ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		for i, group := range config.Files {
+			if !matchGlob(cleanName(group.Source), name) || ignored(group.Ignore, name) {
+				continue
+			}
+
+			groups[i] = append(groups[i], ResolvedFile{
+				Name:                    name,
+				Path:                    name,
+				LocalPath:               filepath.Join(resolved.BasePath, filepath.FromSlash(name)),
+				Translation:             strings.Replace(group.Translation, "**", doubleStar(group.Source, name), 1),
+				Type:                    group.Type,
+				Scheme:                  group.Scheme,
+				FirstLineContainsHeader: group.FirstLineContainsHeader,
+				LanguagesMapping:        group.LanguagesMapping,
+				basePath:                resolved.BasePath,
+			})
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// directories shared by the sources without dest, removed from their names in the project
+	var shared string
+	if !config.PreserveHierarchy {
+		var dirs []string
+		for i, files := range groups {
+			if config.Files[i].Dest != "" {
+				continue
+			}
+			for _, file := range files {
+				dirs = append(dirs, path.Dir(file.Path))
+			}
+		}
+		shared = commonDir(dirs)
+	}
+
+	names := make(map[string]string)
+	for i, files := range groups {
+		for _, file := range files {
+
+			if dest := config.Files[i].Dest; dest != "" {
+				file.Name = expandPattern(dest, "", file.Path, nil)
+			} else if shared != "" {
+				file.Name = strings.TrimPrefix(file.Path, shared+"/")
+			}
+
+			if other, ok := names[file.Name]; ok {
+				return nil, invalid("%v and %v have the same name %v in the project", other, file.Path, file.Name)
+			}
+			names[file.Name] = file.Path
+
+			resolved.Files = append(resolved.Files, file)
+		}
+	}
+
+	return resolved, nil
+}
+
+// ignored reports whether the file or one of its directories matches one of the patterns.
+func ignored(patterns []string, name string) bool {
+
+	for _, pattern := range patterns {
+		pattern = cleanName(pattern)
+		for dir := name; dir != "."; dir = path.Dir(dir) {
+			if matchGlob(pattern, dir) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// doubleStar returns the directories of the name matched by "**" of the pattern,
+// e.g. "ui/menus" for "locale/**/*.csv" and "locale/ui/menus/main.csv".
+func doubleStar(pattern, name string) string {
+
+	patternSegments := strings.Split(cleanName(pattern), "/")
+	nameSegments := strings.Split(name, "/")
+
+	for i, segment := range patternSegments {
+		if segment == "**" {
+			after := len(patternSegments) - i - 1
+			return strings.Join(nameSegments[i:len(nameSegments)-after], "/")
+		}
+	}
+
+	return ""
+}
+
+// commonDir returns the longest directory shared by all the directories, "" if there is none.
+func commonDir(dirs []string) string {
+
+	if len(dirs) == 0 {
+		return ""
+	}
+
+	common := strings.Split(dirs[0], "/")
+	for _, dir := range dirs[1:] {
+		segments := strings.Split(dir, "/")
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	result := strings.Join(common, "/")
+	if result == "." {
+		return ""
+	}
+	return result
+}
+
+// ConfigSyncOptions are options for SyncConfig call
+type ConfigSyncOptions struct {
+	// Update only the sources modified after their last update in Crowdin, see SyncOptions.ModifiedOnly.
+	ModifiedOnly bool
+
+	// Only plan the changes, without applying them.
+	DryRun bool
+}
+
+// SyncConfig - Add the new sources of the config and update the existing ones, with the type, scheme and header
+// of their groups. Remote files that aren't in the config are never deleted.
+func (crowdin *Crowdin) SyncConfig(config *ResolvedConfig, options *ConfigSyncOptions) (*SyncPlan, error) {
+	return crowdin.SyncConfigContext(context.Background(), config, options)
+}
+
+// SyncConfigContext - Same as SyncConfig, with a context.
+func (crowdin *Crowdin) SyncConfigContext(ctx context.Context, config *ResolvedConfig, options *ConfigSyncOptions) (*SyncPlan, error) {
+
+	if config == nil {
+		return nil, invalid("config can't be nil")
+	}
+
+	if options == nil {
+		options = &ConfigSyncOptions{}
+	}
+
+	local := make(map[string]localFile)
+	settings := make(map[string]fileSettings)
+
+	for _, file := range config.Files {
+		fileInfo, err := os.Stat(file.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+
+		local[file.Name] = localFile{path: file.LocalPath, modTime: fileInfo.ModTime()}
+		settings[file.Name] = fileSettings{
+			Type:                    file.Type,
+			Scheme:                  file.Scheme,
+			FirstLineContainsHeader: file.FirstLineContainsHeader,
+		}
+	}
+
+	info, err := crowdin.GetProjectDetailsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := syncPlan(&SyncOptions{ModifiedOnly: options.ModifiedOnly}, local, info.Files)
+	if options.DryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	return plan, crowdin.applySync(ctx, plan, func(name string) fileSettings { return settings[name] })
+}
+
+// DownloadConfig - Download the package of translations and extract the translations of the sources of the config
+// to the paths of their translation patterns. Package is a language code, or "all" if empty.
+func (crowdin *Crowdin) DownloadConfig(config *ResolvedConfig, pkg string) (*ExtractResult, error) {
+	return crowdin.DownloadConfigContext(context.Background(), config, pkg)
+}
+
+// DownloadConfigContext - Same as DownloadConfig, with a context.
+func (crowdin *Crowdin) DownloadConfigContext(ctx context.Context, config *ResolvedConfig, pkg string) (*ExtractResult, error) {
+
+	if config == nil {
+		return nil, invalid("config can't be nil")
+	}
+
+	if pkg == "" {
+		pkg = "all"
+	}
+
+	type layout struct {
+		Name             string
+		Translation      string
+		LanguagesMapping map[string]map[string]string
+	}
+
+	files := make(map[string]*ResolvedFile, len(config.Files))
+	layouts := make([]layout, 0, len(config.Files))
+	for i := range config.Files {
+		file := &config.Files[i]
+		files[file.Name] = file
+		layouts = append(layouts, layout{file.Name, file.Translation, file.LanguagesMapping})
+	}
+
+	var result *ExtractResult
+	cached, err := crowdin.cachedDownload(ctx, "config/"+pkg+" "+config.BasePath+" "+settingsKey(layouts), func() ([]string, error) {
+		var err error
+		result, err = crowdin.extract(ctx, pkg, config.BasePath, func(language, name string) (string, bool) {
+			file, ok := files[name]
+			if !ok {
+				return "", false
+			}
+			return file.translationName(language), true
+		})
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, file := range result.Files {
+			paths = append(paths, file.LocalPath)
+		}
+		return paths, nil
+	})
+
+	if cached {
+		return &ExtractResult{Cached: true}, nil
+	}
+	return result, err
+}
@@ -0,0 +1,290 @@
+package crowdin
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testConfig = `
+project_identifier: my-game
+api_key: file-key
+api_key_env: GAME_CROWDIN_KEY
+base_path: project
+update_option: update_as_unapproved
+files:
+  - source: /locale/en/**/*.csv
+    translation: /locale/%two_letters_code%/**/%original_file_name%
+    ignore:
+      - /locale/en/drafts
+    type: csv
+    scheme: identifier,source_phrase,context
+    first_line_contains_header: true
+    languages_mapping:
+      two_letters_code:
+        pt-BR: pt-br
+  - source: /ios/en.lproj/*.strings
+    translation: /ios/%osx_code%/%original_file_name%
+    dest: /ios/%original_file_name%
+`
+
+func writeTestFiles(t *testing.T, dir string, names ...string) {
+	for _, name := range names {
+		local := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(local), 0755)
+		if err := os.WriteFile(local, []byte("play,Play\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if config.ProjectIdentifier != "my-game" || config.APIKeyEnv != "GAME_CROWDIN_KEY" || config.BasePath != "project" {
+		t.Errorf("Unexpected config %+v", config)
+	}
+	if len(config.Files) != 2 {
+		t.Fatalf("Expected %v, got %v", 2, len(config.Files))
+	}
+
+	group := config.Files[0]
+	if group.Type != FileTypeCSV || group.Scheme != NewScheme(SchemeIdentifier, SchemeSourcePhrase, SchemeContext) || !group.FirstLineContainsHeader {
+		t.Errorf("Unexpected group %+v", group)
+	}
+	if group.LanguagesMapping["two_letters_code"]["pt-BR"] != "pt-br" {
+		t.Errorf("Expected %v, got %v", "pt-br", group.LanguagesMapping)
+	}
+
+	if _, err := ParseConfig([]byte("files: [")); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestConfigFile_Validate(t *testing.T) {
+	tests := []FileGroup{
+		{Translation: "%locale%/%original_file_name%"},
+		{Source: "*.csv"},
+		{Source: "**/a/**/*.csv", Translation: "%locale%/%original_file_name%"},
+		{Source: "*.csv", Translation: "translations/%original_file_name%"},
+		{Source: "*.csv", Translation: "%language%/%original_file_name%"},
+		{Source: "*.csv", Translation: "%locale%/%original_file_name%", Dest: "%locale%.csv"},
+		{Source: "*.csv", Translation: "%locale%/%original_file_name%", Type: "doc"},
+		{Source: "*.csv", Translation: "%locale%/%original_file_name%", Scheme: "identifier,unknown"},
+	}
+
+	for _, group := range tests {
+		config := &ConfigFile{Files: []FileGroup{group}}
+		if err := config.Validate(); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%+v: Expected %v, got %v", group, ErrInvalidOptions, err)
+		}
+	}
+
+	if err := (&ConfigFile{}).Validate(); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected %v, got %v", ErrInvalidOptions, err)
+	}
+}
+
+func TestConfigFile_Resolve(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, filepath.Join(dir, "project"),
+		"locale/en/common.csv",
+		"locale/en/ui/menu.csv",
+		"locale/en/ui/dialogs/quit.csv",
+		"locale/en/drafts/new.csv",
+		"locale/en/readme.txt",
+		"locale/ru/ui/menu.csv",
+		"ios/en.lproj/Localizable.strings",
+	)
+
+	config, err := ParseConfig([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	getenv := func(name string) string {
+		if name == "GAME_CROWDIN_KEY" {
+			return "env-key"
+		}
+		return ""
+	}
+
+	resolved, err := config.Resolve(dir, getenv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resolved.Project != "my-game" || resolved.APIKey != "env-key" || resolved.BasePath != filepath.Join(dir, "project") {
+		t.Errorf("Unexpected config %+v", resolved)
+	}
+
+	var names []string
+	for _, file := range resolved.Files {
+		names = append(names, file.Name)
+	}
+	want := []string{"common.csv", "ui/dialogs/quit.csv", "ui/menu.csv", "ios/Localizable.strings"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+
+	quit := resolved.Files[1]
+	if quit.Path != "locale/en/ui/dialogs/quit.csv" || quit.Type != FileTypeCSV || !quit.FirstLineContainsHeader {
+		t.Errorf("Unexpected file %+v", quit)
+	}
+	if got, want := quit.TranslationPath("pt-BR"), filepath.Join(dir, "project", "locale", "pt-br", "ui", "dialogs", "quit.csv"); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	strings := resolved.Files[3]
+	if got, want := strings.TranslationPath("zh-CN"), filepath.Join(dir, "project", "ios", "zh-Hans.lproj", "Localizable.strings"); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// %original_path% is the directory of the file in the project, not the local one
+	config.Files[0].Translation = "/locale/%two_letters_code%/%original_path%/%original_file_name%"
+	resolved, err = config.Resolve(dir, getenv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, want := resolved.Files[2].TranslationPath("ru"), filepath.Join(dir, "project", "locale", "ru", "ui", "menu.csv"); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	config.PreserveHierarchy = true
+	resolved, err = config.Resolve(dir, getenv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resolved.Files[1].Name != "locale/en/ui/dialogs/quit.csv" {
+		t.Errorf("Expected %v, got %v", "locale/en/ui/dialogs/quit.csv", resolved.Files[1].Name)
+	}
+}
+
+func TestConfigFile_Resolve_duplicates(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, "a/menu.csv", "b/menu.csv")
+
+	config := &ConfigFile{Files: []FileGroup{{
+		Source:      "**/*.csv",
+		Translation: "%locale%/%original_file_name%",
+		Dest:        "%original_file_name%",
+	}}}
+
+	if _, err := config.Resolve(dir, os.Getenv); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected %v, got %v", ErrInvalidOptions, err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, "strings/menu.csv")
+
+	name := filepath.Join(dir, "crowdin.yml")
+	os.WriteFile(name, []byte("project_identifier: my-game\nfiles:\n  - source: /strings/*.csv\n    translation: /strings/%locale%/%original_file_name%\n"), 0644)
+
+	config, err := LoadConfig(name)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(config.Files) != 1 || config.Files[0].Name != "menu.csv" || config.BasePath != dir {
+		t.Errorf("Unexpected config %+v", config)
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.yml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected %v, got %v", os.ErrNotExist, err)
+	}
+}
+
+func TestDoubleStar(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    string
+	}{
+		{"/locale/en/**/*.csv", "locale/en/ui/menus/main.csv", "ui/menus"},
+		{"/locale/en/**/*.csv", "locale/en/main.csv", ""},
+		{"**/strings/*.csv", "a/b/strings/main.csv", "a/b"},
+		{"/locale/en/*.csv", "locale/en/main.csv", ""},
+	}
+
+	for _, test := range tests {
+		if got := doubleStar(test.pattern, test.name); got != test.want {
+			t.Errorf("%v %v: expected %v, got %v", test.pattern, test.name, test.want, got)
+		}
+	}
+}
+
+func TestCommonDir(t *testing.T) {
+	tests := []struct {
+		dirs []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"locale/en/ui"}, "locale/en/ui"},
+		{[]string{"locale/en/ui", "locale/en/ui/dialogs", "locale/en"}, "locale/en"},
+		{[]string{"locale/en", "."}, ""},
+		{[]string{"a/b", "a/bc"}, "a"},
+	}
+
+	for _, test := range tests {
+		if got := commonDir(test.dirs); got != test.want {
+			t.Errorf("%v: expected %v, got %v", test.dirs, test.want, got)
+		}
+	}
+}
+
+func TestCrowdin_DownloadConfig_cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"finished","progress":100,"last_build":"2017-06-06T12:44:56+0000"}`))
+	})
+	serveZip(t, zipEntry{name: "ru/menu.csv", content: "play,Играть\n"})
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, "en/menu.csv")
+
+	file := &ConfigFile{Files: []FileGroup{{Source: "/en/*.csv", Translation: "/%two_letters_code%/%original_file_name%"}}}
+	resolve := func() *ResolvedConfig {
+		t.Helper()
+		config, err := file.Resolve(dir, os.Getenv)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return config
+	}
+
+	crowdin.SetCache(NewMemoryCache())
+
+	config := resolve()
+	if result, err := crowdin.DownloadConfig(config, "all"); err != nil || result.Cached {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+	if result, err := crowdin.DownloadConfig(config, "all"); err != nil || !result.Cached {
+		t.Errorf("Expected cached result, got %+v, %v", result, err)
+	}
+
+	// new translation patterns and mappings write other files, even if nothing was built
+	file.Files[0].Translation = "/translations/%two_letters_code%/%original_file_name%"
+	if result, err := crowdin.DownloadConfig(resolve(), "all"); err != nil || result.Cached {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "translations", "ru", "menu.csv")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	file.Files[0].LanguagesMapping = map[string]map[string]string{"two_letters_code": {"ru": "russian"}}
+	if result, err := crowdin.DownloadConfig(resolve(), "all"); err != nil || result.Cached {
+		t.Fatalf("Unexpected %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "translations", "russian", "menu.csv")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
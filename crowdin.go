@@ -1,15 +1,14 @@
 package crowdin
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"os"
+	"sync"
 	"time"
-
-	"github.com/mreiferson/go-httpclient"
 )
 
 var (
@@ -24,34 +23,34 @@ type Crowdin struct {
 		apiAccountBaseURL string
 		token             string
 		project           string
+		userAgent         string
 		client            *http.Client
 	}
-	debug     bool
-	logWriter io.Writer
+	debug       bool
+	logWriter   io.Writer
+	retryPolicy *RetryPolicy
+	middlewares []Middleware
+	logger      *slog.Logger
+	cache       Cache
+	exportStore ExportStore
+	batch       struct {
+		size        int
+		parallelism int
+	}
+
+	// guards all the fields above, so the client can be shared by goroutines
+	mu sync.RWMutex
 }
 
 // New - create new instance of Crowdin API.
 func New(token, project string) *Crowdin {
-
-	transport := &httpclient.Transport{
-		ConnectTimeout:   5 * time.Second,
-		ReadWriteTimeout: 40 * time.Second,
-	}
-	defer transport.Close()
-
-	s := &Crowdin{}
-	s.config.apiBaseURL = apiBaseURL
-	s.config.apiAccountBaseURL = apiAccountBaseURL
-	s.config.token = token
-	s.config.project = project
-	s.config.client = &http.Client{
-		Transport: transport,
-	}
-	return s
+	return NewWithOptions(token, project)
 }
 
 // SetProject set project details
 func (crowdin *Crowdin) SetProject(token, project string) *Crowdin {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
 	crowdin.config.token = token
 	crowdin.config.project = project
 	return crowdin
@@ -59,17 +58,34 @@ func (crowdin *Crowdin) SetProject(token, project string) *Crowdin {
 
 // SetDebug - traces errors if it's set to true.
 func (crowdin *Crowdin) SetDebug(debug bool, logWriter io.Writer) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
 	crowdin.debug = debug
 	crowdin.logWriter = logWriter
 }
 
 // SetClient sets a custom http client. Can be useful in App Engine case.
 func (crowdin *Crowdin) SetClient(client *http.Client) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
 	crowdin.config.client = client
 }
 
 // AddFile - Add new file to Crowdin project.
-func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*responseAddFile, error) {
+func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*AddFileResult, error) {
+	return crowdin.AddFileContext(context.Background(), options)
+}
+
+// AddFileContext - Same as AddFile, with a context.
+func (crowdin *Crowdin) AddFileContext(ctx context.Context, options *AddFileOptions) (*AddFileResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if batches := crowdin.batches(options.Files, options.Sources); len(batches) > 1 {
+		return crowdin.addFileBatches(ctx, options, batches)
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -77,11 +93,11 @@ func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*responseAddFile, erro
 	if options != nil {
 
 		if options.Type != "" {
-			params["type"] = options.Type
+			params["type"] = string(options.Type)
 		}
 
 		if options.Scheme != "" {
-			params["scheme"] = options.Scheme
+			params["scheme"] = string(options.Scheme)
 		}
 
 		if options.FirstLineContainsHeader {
@@ -92,17 +108,16 @@ func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*responseAddFile, erro
 
 	}
 
-	files := make(map[string]string)
-	if options != nil && options.Files != nil {
-		for k, path := range options.Files {
-			files[fmt.Sprintf("files[%v]", k)] = path
-		}
+	var files map[string]FileSource
+	if options != nil {
+		files = formFiles(options.Files, options.Sources)
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/add-file?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
-		files:  files,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "add-file",
+		urlStr:   crowdin.projectURL("add-file"),
+		params:   params,
+		files:    files,
 	})
 
 	if err != nil {
@@ -112,7 +127,7 @@ func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*responseAddFile, erro
 
 	crowdin.log(string(response))
 
-	var responseAPI responseAddFile
+	var responseAPI AddFileResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -124,7 +139,20 @@ func (crowdin *Crowdin) AddFile(options *AddFileOptions) (*responseAddFile, erro
 }
 
 // UpdateFile - Upload latest version of your localization file to Crowdin
-func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*responseGeneral, error) {
+func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*UpdateFileResult, error) {
+	return crowdin.UpdateFileContext(context.Background(), options)
+}
+
+// UpdateFileContext - Same as UpdateFile, with a context.
+func (crowdin *Crowdin) UpdateFileContext(ctx context.Context, options *UpdateFileOptions) (*UpdateFileResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if batches := crowdin.batches(options.Files, options.Sources); len(batches) > 1 {
+		return crowdin.updateFileBatches(ctx, options, batches)
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -132,7 +160,7 @@ func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*responseGeneral
 	if options != nil {
 
 		if options.Scheme != "" {
-			params["scheme"] = options.Scheme
+			params["scheme"] = string(options.Scheme)
 		}
 
 		if options.FirstLineContainsHeader {
@@ -143,17 +171,16 @@ func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*responseGeneral
 
 	}
 
-	files := make(map[string]string)
-	if options != nil && options.Files != nil {
-		for k, path := range options.Files {
-			files[fmt.Sprintf("files[%v]", k)] = path
-		}
+	var files map[string]FileSource
+	if options != nil {
+		files = formFiles(options.Files, options.Sources)
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/update-file?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
-		files:  files,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "update-file",
+		urlStr:   crowdin.projectURL("update-file"),
+		params:   params,
+		files:    files,
 	})
 
 	if err != nil {
@@ -163,7 +190,7 @@ func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*responseGeneral
 
 	crowdin.log(string(response))
 
-	var responseAPI responseGeneral
+	var responseAPI UpdateFileResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -175,15 +202,21 @@ func (crowdin *Crowdin) UpdateFile(options *UpdateFileOptions) (*responseGeneral
 }
 
 // DeleteFile - Delete file from Crowdin project. All the translations will be lost without ability to restore them
-func (crowdin *Crowdin) DeleteFile(fileName string) (*responseGeneral, error) {
+func (crowdin *Crowdin) DeleteFile(fileName string) (*GeneralResult, error) {
+	return crowdin.DeleteFileContext(context.Background(), fileName)
+}
+
+// DeleteFileContext - Same as DeleteFile, with a context.
+func (crowdin *Crowdin) DeleteFileContext(ctx context.Context, fileName string) (*GeneralResult, error) {
 
 	params := make(map[string]string)
 	params["json"] = ""
 	params["file"] = fileName
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/delete-file?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "delete-file",
+		urlStr:   crowdin.projectURL("delete-file"),
+		params:   params,
 	})
 
 	if err != nil {
@@ -193,7 +226,7 @@ func (crowdin *Crowdin) DeleteFile(fileName string) (*responseGeneral, error) {
 
 	crowdin.log(string(response))
 
-	var responseAPI responseGeneral
+	var responseAPI GeneralResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -205,7 +238,20 @@ func (crowdin *Crowdin) DeleteFile(fileName string) (*responseGeneral, error) {
 }
 
 // UploadTranslations - Upload latest version of your localization file to Crowdin
-func (crowdin *Crowdin) UploadTranslations(options *UploadTranslationsOptions) (*responseUploadTranslation, error) {
+func (crowdin *Crowdin) UploadTranslations(options *UploadTranslationsOptions) (*UploadTranslationResult, error) {
+	return crowdin.UploadTranslationsContext(context.Background(), options)
+}
+
+// UploadTranslationsContext - Same as UploadTranslations, with a context.
+func (crowdin *Crowdin) UploadTranslationsContext(ctx context.Context, options *UploadTranslationsOptions) (*UploadTranslationResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	if batches := crowdin.batches(options.Files, options.Sources); len(batches) > 1 {
+		return crowdin.uploadTranslationsBatches(ctx, options, batches)
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -220,17 +266,16 @@ func (crowdin *Crowdin) UploadTranslations(options *UploadTranslationsOptions) (
 
 	}
 
-	files := make(map[string]string)
-	if options != nil && options.Files != nil {
-		for k, path := range options.Files {
-			files[fmt.Sprintf("files[%v]", k)] = path
-		}
+	var files map[string]FileSource
+	if options != nil {
+		files = formFiles(options.Files, options.Sources)
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/upload-translation?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
-		files:  files,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "upload-translation",
+		urlStr:   crowdin.projectURL("upload-translation"),
+		params:   params,
+		files:    files,
 	})
 
 	if err != nil {
@@ -240,7 +285,7 @@ func (crowdin *Crowdin) UploadTranslations(options *UploadTranslationsOptions) (
 
 	crowdin.log(string(response))
 
-	var responseAPI responseUploadTranslation
+	var responseAPI UploadTranslationResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -253,9 +298,15 @@ func (crowdin *Crowdin) UploadTranslations(options *UploadTranslationsOptions) (
 
 // GetTranslationsStatus - Track overall translation and proofreading progresses of each target language
 func (crowdin *Crowdin) GetTranslationsStatus() ([]TranslationStatus, error) {
+	return crowdin.GetTranslationsStatusContext(context.Background())
+}
+
+// GetTranslationsStatusContext - Same as GetTranslationsStatus, with a context.
+func (crowdin *Crowdin) GetTranslationsStatusContext(ctx context.Context) ([]TranslationStatus, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/status?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "status",
+		urlStr:   crowdin.projectURL("status"),
 		params: map[string]string{
 			"json": "",
 		},
@@ -280,9 +331,15 @@ func (crowdin *Crowdin) GetTranslationsStatus() ([]TranslationStatus, error) {
 
 // GetExportStatus - Get the status of translations export
 func (crowdin *Crowdin) GetExportStatus() (*ExportStatus, error) {
+	return crowdin.GetExportStatusContext(context.Background())
+}
+
+// GetExportStatusContext - Same as GetExportStatus, with a context.
+func (crowdin *Crowdin) GetExportStatusContext(ctx context.Context) (*ExportStatus, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/export-status?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "export-status",
+		urlStr:   crowdin.projectURL("export-status"),
 		params: map[string]string{
 			"json": "",
 		},
@@ -307,10 +364,16 @@ func (crowdin *Crowdin) GetExportStatus() (*ExportStatus, error) {
 
 // GetLanguageStatus - Get the detailed translation progress for specified language.
 // Language codes - https://crowdin.com/page/api/language-codes
-func (crowdin *Crowdin) GetLanguageStatus(languageCode string) (*responseLanguageStatus, error) {
+func (crowdin *Crowdin) GetLanguageStatus(languageCode string) (*LanguageStatus, error) {
+	return crowdin.GetLanguageStatusContext(context.Background(), languageCode)
+}
+
+// GetLanguageStatusContext - Same as GetLanguageStatus, with a context.
+func (crowdin *Crowdin) GetLanguageStatusContext(ctx context.Context, languageCode string) (*LanguageStatus, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/language-status?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "language-status",
+		urlStr:   crowdin.projectURL("language-status"),
 		params: map[string]string{
 			"language": languageCode,
 			"json":     "",
@@ -324,7 +387,7 @@ func (crowdin *Crowdin) GetLanguageStatus(languageCode string) (*responseLanguag
 
 	crowdin.log(string(response))
 
-	var responseAPI responseLanguageStatus
+	var responseAPI LanguageStatus
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -336,9 +399,15 @@ func (crowdin *Crowdin) GetLanguageStatus(languageCode string) (*responseLanguag
 
 // GetProjectDetails - Get Crowdin Project details
 func (crowdin *Crowdin) GetProjectDetails() (*ProjectInfo, error) {
+	return crowdin.GetProjectDetailsContext(context.Background())
+}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/info?key=%v", crowdin.config.project, crowdin.config.token),
+// GetProjectDetailsContext - Same as GetProjectDetails, with a context.
+func (crowdin *Crowdin) GetProjectDetailsContext(ctx context.Context) (*ProjectInfo, error) {
+
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "info",
+		urlStr:   crowdin.projectURL("info"),
 		params: map[string]string{
 			"json": "",
 		},
@@ -363,32 +432,43 @@ func (crowdin *Crowdin) GetProjectDetails() (*ProjectInfo, error) {
 
 // DownloadTranslations - Download ZIP file with translations. You can choose the language of translation you need or download all of them at once.
 func (crowdin *Crowdin) DownloadTranslations(options *DownloadOptions) error {
+	return crowdin.DownloadTranslationsContext(context.Background(), options)
+}
+
+// DownloadTranslationsContext - Same as DownloadTranslations, with a context.
+func (crowdin *Crowdin) DownloadTranslationsContext(ctx context.Context, options *DownloadOptions) error {
 
 	if options == nil || options.Package == "" {
 		return errors.New("Package can't be empty")
 	}
 
-	response, err := crowdin.getResponse(&getOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/download/%v.zip?key=%v", crowdin.config.project, options.Package, crowdin.config.token),
+	_, err := crowdin.cachedDownload(ctx, "download/"+options.Package+".zip "+options.LocalPath, func() ([]string, error) {
+		return []string{options.LocalPath}, createFile(options.LocalPath, func(w io.Writer) error {
+			return crowdin.DownloadTranslationsToContext(ctx, w, options)
+		})
 	})
+	return err
+}
 
-	defer response.Body.Close()
+// DownloadTranslationsTo - Same as DownloadTranslations, but the ZIP file is written to w. LocalPath is ignored.
+func (crowdin *Crowdin) DownloadTranslationsTo(w io.Writer, options *DownloadOptions) error {
+	return crowdin.DownloadTranslationsToContext(context.Background(), w, options)
+}
 
-	if err != nil {
-		crowdin.log(err)
-		return err
-	}
+// DownloadTranslationsToContext - Same as DownloadTranslationsTo, with a context.
+func (crowdin *Crowdin) DownloadTranslationsToContext(ctx context.Context, w io.Writer, options *DownloadOptions) error {
 
-	// create the file
-	out, err := os.Create(options.LocalPath)
-	if err != nil {
-		return err
+	if options == nil || options.Package == "" {
+		return errors.New("Package can't be empty")
 	}
-	defer out.Close()
 
-	// writer the body to file
-	_, err = io.Copy(out, response.Body)
+	err := crowdin.download(ctx, w, &getOptions{
+		endpoint: "download",
+		urlStr:   crowdin.projectURL("download/" + options.Package + ".zip"),
+	})
+
 	if err != nil {
+		crowdin.log(err)
 		return err
 	}
 
@@ -397,42 +477,51 @@ func (crowdin *Crowdin) DownloadTranslations(options *DownloadOptions) error {
 
 // ExportFile - This method exports single translated files from Crowdin. Additionally, it can be applied to export XLIFF files for offline localization.
 func (crowdin *Crowdin) ExportFile(options *ExportFileOptions) error {
+	return crowdin.ExportFileContext(context.Background(), options)
+}
 
-	params := make(map[string]string)
-
-	if options != nil {
-
-		if options.Language != "" {
-			params["language"] = options.Language
-		}
+// ExportFileContext - Same as ExportFile, with a context.
+func (crowdin *Crowdin) ExportFileContext(ctx context.Context, options *ExportFileOptions) error {
 
-		if options.CrowdinFile != "" {
-			params["file"] = options.CrowdinFile
-		}
+	if options == nil || options.CrowdinFile == "" {
+		return errors.New("CrowdinFile can't be empty")
 	}
 
-	response, err := crowdin.getResponse(&getOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/export-file?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
+	_, err := crowdin.cachedDownload(ctx, "export-file/"+options.Language+"/"+options.CrowdinFile+" "+options.LocalPath, func() ([]string, error) {
+		return []string{options.LocalPath}, createFile(options.LocalPath, func(w io.Writer) error {
+			return crowdin.ExportFileToContext(ctx, w, options)
+		})
 	})
+	return err
+}
 
-	defer response.Body.Close()
+// ExportFileTo - Same as ExportFile, but the exported file is written to w. LocalPath is ignored.
+func (crowdin *Crowdin) ExportFileTo(w io.Writer, options *ExportFileOptions) error {
+	return crowdin.ExportFileToContext(context.Background(), w, options)
+}
 
-	if err != nil {
-		crowdin.log(err)
-		return err
+// ExportFileToContext - Same as ExportFileTo, with a context.
+func (crowdin *Crowdin) ExportFileToContext(ctx context.Context, w io.Writer, options *ExportFileOptions) error {
+
+	if options == nil || options.CrowdinFile == "" {
+		return errors.New("CrowdinFile can't be empty")
 	}
 
-	// create the file
-	out, err := os.Create(options.LocalPath)
-	if err != nil {
-		return err
+	params := make(map[string]string)
+	params["file"] = options.CrowdinFile
+
+	if options.Language != "" {
+		params["language"] = options.Language
 	}
-	defer out.Close()
 
-	// writer the body to file
-	_, err = io.Copy(out, response.Body)
+	err := crowdin.download(ctx, w, &getOptions{
+		endpoint: "export-file",
+		urlStr:   crowdin.projectURL("export-file"),
+		params:   params,
+	})
+
 	if err != nil {
+		crowdin.log(err)
 		return err
 	}
 
@@ -440,10 +529,30 @@ func (crowdin *Crowdin) ExportFile(options *ExportFileOptions) error {
 }
 
 // ExportTranslations - Build ZIP archive with the latest translations. Please note that this method can be invoked only once per 30 minutes (there is no such restriction for organization plans). Also API call will be ignored if there were no changes in the project since previous export. You can see whether ZIP archive with latest translations was actually build by status attribute ("built" or "skipped") returned in response.
-func (crowdin *Crowdin) ExportTranslations() (*responseExportTranslations, error) {
+func (crowdin *Crowdin) ExportTranslations() (*ExportTranslationsResult, error) {
+	return crowdin.ExportTranslationsContext(context.Background())
+}
+
+// ExportTranslationsContext - Same as ExportTranslations, with a context.
+func (crowdin *Crowdin) ExportTranslationsContext(ctx context.Context) (*ExportTranslationsResult, error) {
+
+	crowdin.mu.RLock()
+	store := crowdin.exportStore
+	project := crowdin.config.project
+	crowdin.mu.RUnlock()
+
+	if store != nil {
+		if last, ok := store.LastExport(project); ok && time.Since(last) < ExportInterval {
+			responseAPI := &ExportTranslationsResult{ThrottledUntil: last.Add(ExportInterval)}
+			responseAPI.Success.Status = ExportThrottled
+			crowdin.log("Export of " + project + " is throttled until " + responseAPI.ThrottledUntil.String())
+			return responseAPI, nil
+		}
+	}
 
-	response, err := crowdin.get(&getOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/export?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.get(ctx, &getOptions{
+		endpoint: "export",
+		urlStr:   crowdin.projectURL("export"),
 		params: map[string]string{
 			"json": "",
 		},
@@ -456,21 +565,34 @@ func (crowdin *Crowdin) ExportTranslations() (*responseExportTranslations, error
 
 	crowdin.log(string(response))
 
-	var responseAPI responseExportTranslations
+	var responseAPI ExportTranslationsResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
 		return nil, err
 	}
 
+	if store != nil && responseAPI.Success.Status == ExportBuilt {
+		if err := store.SetLastExport(project, time.Now()); err != nil {
+			crowdin.log(err)
+			return &responseAPI, err
+		}
+	}
+
 	return &responseAPI, nil
 }
 
 // GetAccountProjects - Get Crowdin Project details.
 func (crowdin *Crowdin) GetAccountProjects(accountKey, loginUsername string) (*AccountDetails, error) {
+	return crowdin.GetAccountProjectsContext(context.Background(), accountKey, loginUsername)
+}
+
+// GetAccountProjectsContext - Same as GetAccountProjects, with a context.
+func (crowdin *Crowdin) GetAccountProjectsContext(ctx context.Context, accountKey, loginUsername string) (*AccountDetails, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiAccountBaseURL+"get-projects?account-key=%v", accountKey),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "get-projects",
+		urlStr:   crowdin.accountURL("get-projects", accountKey),
 		params: map[string]string{
 			"login": loginUsername,
 			"json":  "",
@@ -495,7 +617,16 @@ func (crowdin *Crowdin) GetAccountProjects(accountKey, loginUsername string) (*A
 }
 
 // CreateProject - Create Crowdin project.
-func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options *CreateProjectOptions) (*responseManageProject, error) {
+func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options *CreateProjectOptions) (*ManageProjectResult, error) {
+	return crowdin.CreateProjectContext(context.Background(), accountKey, loginUsername, options)
+}
+
+// CreateProjectContext - Same as CreateProject, with a context.
+func (crowdin *Crowdin) CreateProjectContext(ctx context.Context, accountKey, loginUsername string, options *CreateProjectOptions) (*ManageProjectResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -518,7 +649,7 @@ func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options
 		}
 
 		if options.JoinPolicy != "" {
-			params["join_policy"] = options.JoinPolicy
+			params["join_policy"] = string(options.JoinPolicy)
 		}
 
 		if options.Languages != nil {
@@ -526,8 +657,9 @@ func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options
 		}
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr:      fmt.Sprintf(crowdin.config.apiAccountBaseURL+"create-project?account-key=%v", accountKey),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint:    "create-project",
+		urlStr:      crowdin.accountURL("create-project", accountKey),
 		params:      params,
 		paramsArray: paramsArray,
 	})
@@ -539,7 +671,7 @@ func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options
 
 	crowdin.log(string(response))
 
-	var responseAPI responseManageProject
+	var responseAPI ManageProjectResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -550,7 +682,16 @@ func (crowdin *Crowdin) CreateProject(accountKey, loginUsername string, options
 }
 
 // EditProject - Edit Crowdin project.
-func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*responseManageProject, error) {
+func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*ManageProjectResult, error) {
+	return crowdin.EditProjectContext(context.Background(), options)
+}
+
+// EditProjectContext - Same as EditProject, with a context.
+func (crowdin *Crowdin) EditProjectContext(ctx context.Context, options *EditProjectOptions) (*ManageProjectResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -564,7 +705,7 @@ func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*responseManag
 		}
 
 		if options.JoinPolicy != "" {
-			params["join_policy"] = options.JoinPolicy
+			params["join_policy"] = string(options.JoinPolicy)
 		}
 
 		if options.Languages != nil {
@@ -572,8 +713,9 @@ func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*responseManag
 		}
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr:      fmt.Sprintf(crowdin.config.apiBaseURL+"%v/edit-project?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint:    "edit-project",
+		urlStr:      crowdin.projectURL("edit-project"),
 		params:      params,
 		paramsArray: paramsArray,
 	})
@@ -585,7 +727,7 @@ func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*responseManag
 
 	crowdin.log(string(response))
 
-	var responseAPI responseManageProject
+	var responseAPI ManageProjectResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -596,14 +738,20 @@ func (crowdin *Crowdin) EditProject(options *EditProjectOptions) (*responseManag
 }
 
 // DeleteProject - Delete Crowdin project with all translations.
-func (crowdin *Crowdin) DeleteProject() (*responseDeleteProject, error) {
+func (crowdin *Crowdin) DeleteProject() (*DeleteProjectResult, error) {
+	return crowdin.DeleteProjectContext(context.Background())
+}
+
+// DeleteProjectContext - Same as DeleteProject, with a context.
+func (crowdin *Crowdin) DeleteProjectContext(ctx context.Context) (*DeleteProjectResult, error) {
 
 	params := make(map[string]string)
 	params["json"] = ""
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/delete-project?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "delete-project",
+		urlStr:   crowdin.projectURL("delete-project"),
+		params:   params,
 	})
 
 	if err != nil {
@@ -613,7 +761,7 @@ func (crowdin *Crowdin) DeleteProject() (*responseDeleteProject, error) {
 
 	crowdin.log(string(response))
 
-	var responseAPI responseDeleteProject
+	var responseAPI DeleteProjectResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -625,10 +773,16 @@ func (crowdin *Crowdin) DeleteProject() (*responseDeleteProject, error) {
 
 // AddDirectory - Add directory to Crowdin project.
 // name - Directory name (with path if nested directory should be created).
-func (crowdin *Crowdin) AddDirectory(directoryName string) (*responseGeneral, error) {
+func (crowdin *Crowdin) AddDirectory(directoryName string) (*GeneralResult, error) {
+	return crowdin.AddDirectoryContext(context.Background(), directoryName)
+}
+
+// AddDirectoryContext - Same as AddDirectory, with a context.
+func (crowdin *Crowdin) AddDirectoryContext(ctx context.Context, directoryName string) (*GeneralResult, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/add-directory?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "add-directory",
+		urlStr:   crowdin.projectURL("add-directory"),
 		params: map[string]string{
 			"name": directoryName,
 			"json": "",
@@ -642,7 +796,7 @@ func (crowdin *Crowdin) AddDirectory(directoryName string) (*responseGeneral, er
 
 	crowdin.log(string(response))
 
-	var responseAPI responseGeneral
+	var responseAPI GeneralResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -653,7 +807,16 @@ func (crowdin *Crowdin) AddDirectory(directoryName string) (*responseGeneral, er
 }
 
 // ChangeDirectory - Rename directory or modify its attributes. When renaming directory the path can not be changed (it means new_name parameter can not contain path, name only).
-func (crowdin *Crowdin) ChangeDirectory(options *ChangeDirectoryOptions) (*responseGeneral, error) {
+func (crowdin *Crowdin) ChangeDirectory(options *ChangeDirectoryOptions) (*GeneralResult, error) {
+	return crowdin.ChangeDirectoryContext(context.Background(), options)
+}
+
+// ChangeDirectoryContext - Same as ChangeDirectory, with a context.
+func (crowdin *Crowdin) ChangeDirectoryContext(ctx context.Context, options *ChangeDirectoryOptions) (*GeneralResult, error) {
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
 
 	params := make(map[string]string)
 	params["json"] = ""
@@ -673,9 +836,10 @@ func (crowdin *Crowdin) ChangeDirectory(options *ChangeDirectoryOptions) (*respo
 		}
 	}
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/change-directory?key=%v", crowdin.config.project, crowdin.config.token),
-		params: params,
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "change-directory",
+		urlStr:   crowdin.projectURL("change-directory"),
+		params:   params,
 	})
 
 	if err != nil {
@@ -685,7 +849,7 @@ func (crowdin *Crowdin) ChangeDirectory(options *ChangeDirectoryOptions) (*respo
 
 	crowdin.log(string(response))
 
-	var responseAPI responseGeneral
+	var responseAPI GeneralResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
@@ -697,10 +861,16 @@ func (crowdin *Crowdin) ChangeDirectory(options *ChangeDirectoryOptions) (*respo
 
 // DeleteDirectory - Delete Crowdin project directory. All nested files and directories will be deleted too.
 // name - Directory name (with path if nested directory should be created).
-func (crowdin *Crowdin) DeleteDirectory(directoryName string) (*responseGeneral, error) {
+func (crowdin *Crowdin) DeleteDirectory(directoryName string) (*GeneralResult, error) {
+	return crowdin.DeleteDirectoryContext(context.Background(), directoryName)
+}
+
+// DeleteDirectoryContext - Same as DeleteDirectory, with a context.
+func (crowdin *Crowdin) DeleteDirectoryContext(ctx context.Context, directoryName string) (*GeneralResult, error) {
 
-	response, err := crowdin.post(&postOptions{
-		urlStr: fmt.Sprintf(crowdin.config.apiBaseURL+"%v/delete-directory?key=%v", crowdin.config.project, crowdin.config.token),
+	response, err := crowdin.post(ctx, &postOptions{
+		endpoint: "delete-directory",
+		urlStr:   crowdin.projectURL("delete-directory"),
 		params: map[string]string{
 			"name": directoryName,
 			"json": "",
@@ -714,7 +884,7 @@ func (crowdin *Crowdin) DeleteDirectory(directoryName string) (*responseGeneral,
 
 	crowdin.log(string(response))
 
-	var responseAPI responseGeneral
+	var responseAPI GeneralResult
 	err = json.Unmarshal(response, &responseAPI)
 	if err != nil {
 		crowdin.log(err)
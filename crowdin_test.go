@@ -1,8 +1,17 @@
 package crowdin
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNew_setToken(t *testing.T) {
@@ -40,3 +49,149 @@ func TestGitter_SetClient(t *testing.T) {
 		t.Logf("Expected %v, got %v", c, crowdin.config.client)
 	}
 }
+
+func TestCrowdin_AddDirectoryContext_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	called := false
+	mux.HandleFunc("/project-name/add-directory", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := crowdin.AddDirectoryContext(ctx, "strings")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+	if called {
+		t.Errorf("Expected request not to be sent")
+	}
+}
+
+func TestCrowdin_DownloadTranslationsContext_deadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/download/all.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("PK"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := crowdin.DownloadTranslationsContext(ctx, &DownloadOptions{
+		Package:   "all",
+		LocalPath: filepath.Join(t.TempDir(), "all.zip"),
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestCrowdin_AddFile_streamsFiles(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"a.csv", "b.csv", "c.csv"} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(name), 0644)
+		files[name] = path
+	}
+
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("Expected streamed body, got content length %v", r.ContentLength)
+		}
+		for name := range files {
+			file, _, err := r.FormFile("files[" + name + "]")
+			if err != nil {
+				t.Fatalf("Expected %v in request, got %v", name, err)
+			}
+			content, _ := io.ReadAll(file)
+			if string(content) != name {
+				t.Errorf("Expected %v, got %v", name, string(content))
+			}
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	if _, err := crowdin.AddFile(&AddFileOptions{Files: files}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestCrowdin_AddFile_missingFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, RetryUnsafe: true})
+
+	var attempts int32
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		io.Copy(io.Discard, r.Body)
+	})
+
+	_, err := crowdin.AddFile(&AddFileOptions{
+		Files: map[string]string{"strings.csv": filepath.Join(t.TempDir(), "missing.csv")},
+	})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected %v, got %v", fs.ErrNotExist, err)
+	}
+	if got := atomic.LoadInt32(&attempts); got > 1 {
+		t.Errorf("Expected no retries, got %v attempts", got)
+	}
+}
+
+func TestCrowdin_DownloadTranslationsTo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/download/ru.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("PK-ru"))
+	})
+
+	var buffer bytes.Buffer
+	if err := crowdin.DownloadTranslationsTo(&buffer, &DownloadOptions{Package: "ru"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buffer.String() != "PK-ru" {
+		t.Errorf("Expected %v, got %v", "PK-ru", buffer.String())
+	}
+}
+
+func TestCrowdin_DownloadTranslations_errorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/download/all.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	})
+
+	var buffer bytes.Buffer
+	err := crowdin.DownloadTranslationsTo(&buffer, &DownloadOptions{Package: "all"})
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected APIError with status %v, got %v", http.StatusBadGateway, err)
+	}
+	if buffer.Len() != 0 {
+		t.Errorf("Expected nothing written, got %v", buffer.String())
+	}
+
+	localPath := filepath.Join(t.TempDir(), "all.zip")
+	if err := crowdin.DownloadTranslations(&DownloadOptions{Package: "all", LocalPath: localPath}); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if _, err := os.Stat(localPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected %v to be removed, got %v", localPath, err)
+	}
+}
@@ -0,0 +1,819 @@
+package crowdintest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error codes sent by the fake, following Crowdin API v1.
+const (
+	codeInvalidKey        = 3
+	codeNoFiles           = 4
+	codeFileExists        = 5
+	codeFileNotFound      = 8
+	codeTooManyFiles      = 12
+	codeDirectoryNotFound = 17
+	codeDirectoryExists   = 50
+)
+
+const timeLayout = "2006-01-02T15:04:05-0700"
+
+var languageNames = map[string]string{
+	"en":    "English",
+	"ru":    "Russian",
+	"de":    "German",
+	"fr":    "French",
+	"es-ES": "Spanish",
+	"it":    "Italian",
+	"ja":    "Japanese",
+	"ko":    "Korean",
+	"pt-BR": "Portuguese, Brazilian",
+	"zh-CN": "Chinese Simplified",
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	endpoint, pkg, account, ok := parsePath(r.URL.Path, s.Project)
+	if !ok {
+		writeError(w, http.StatusNotFound, 0, "Not found")
+		return
+	}
+
+	files, err := parseForm(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+
+	params := make(map[string][]string)
+	for k, v := range r.Form {
+		if k != "key" && k != "account-key" {
+			params[k] = v
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: endpoint,
+		Method:   r.Method,
+		Header:   r.Header.Clone(),
+		Params:   params,
+		Files:    files,
+	})
+	s.mu.Unlock()
+
+	if fault := s.fault(endpoint); fault != nil {
+		time.Sleep(fault.Delay)
+		for k, v := range fault.Header {
+			w.Header()[k] = v
+		}
+		if fault.Code != 0 {
+			writeError(w, fault.StatusCode, fault.Code, fault.Message)
+			return
+		}
+		w.WriteHeader(fault.StatusCode)
+		io.WriteString(w, fault.Message)
+		return
+	}
+
+	if account && r.URL.Query().Get("account-key") != s.AccountKey ||
+		!account && r.URL.Query().Get("key") != s.Key {
+		writeError(w, http.StatusUnauthorized, codeInvalidKey, "API key is not valid")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch endpoint {
+	case "add-file":
+		s.addFile(w, files)
+	case "update-file":
+		s.updateFile(w, files)
+	case "delete-file":
+		s.deleteFile(w, r.FormValue("file"))
+	case "upload-translation":
+		s.uploadTranslation(w, r.FormValue("language"), files)
+	case "status":
+		s.status(w)
+	case "language-status":
+		s.languageStatus(w, r.FormValue("language"))
+	case "info":
+		s.info(w)
+	case "export":
+		s.export(w)
+	case "export-status":
+		s.exportStatus(w)
+	case "download":
+		s.download(w, pkg)
+	case "export-file":
+		s.exportFile(w, r.FormValue("file"), r.FormValue("language"))
+	case "add-directory":
+		s.addDirectory(w, r.FormValue("name"))
+	case "change-directory":
+		s.changeDirectory(w, r.FormValue("name"), r.FormValue("new_name"), r.FormValue("title"))
+	case "delete-directory":
+		s.deleteDirectory(w, r.FormValue("name"))
+	case "edit-project":
+		s.editProject(w, r.FormValue("name"), r.Form["languages[]"])
+	case "delete-project":
+		s.deleteProject(w)
+	case "get-projects":
+		s.getProjects(w)
+	case "create-project":
+		s.createProject(w, r.FormValue("name"), r.FormValue("identifier"))
+	default:
+		writeError(w, http.StatusNotFound, 0, "Unknown method")
+	}
+}
+
+// parsePath splits "/api/project/{project}/{endpoint}" and "/api/account/{endpoint}" paths.
+// Package is the name of the downloaded zip, e.g. "all" for "/download/all.zip".
+func parsePath(urlPath, projectName string) (endpoint, pkg string, account, ok bool) {
+
+	if rest := strings.TrimPrefix(urlPath, "/api/account/"); rest != urlPath {
+		return rest, "", true, true
+	}
+
+	rest := strings.TrimPrefix(urlPath, "/api/project/"+projectName+"/")
+	if rest == urlPath {
+		return "", "", false, false
+	}
+
+	if strings.HasPrefix(rest, "download/") {
+		return "download", strings.TrimSuffix(strings.TrimPrefix(rest, "download/"), ".zip"), false, true
+	}
+
+	return rest, "", false, true
+}
+
+// parseForm parses query and form params and returns the content of uploaded files.
+func parseForm(r *http.Request) (map[string][]byte, error) {
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return nil, r.ParseForm()
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for key, headers := range r.MultipartForm.File {
+		for _, header := range headers {
+			f, err := header.Open()
+			if err != nil {
+				return nil, err
+			}
+			content, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+			files[key] = content
+		}
+	}
+
+	return files, nil
+}
+
+// fileNames returns the names of the files in "files[name]" form keys, sorted.
+func fileNames(files map[string][]byte) []string {
+	var names []string
+	for key := range files {
+		if strings.HasPrefix(key, "files[") && strings.HasSuffix(key, "]") {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formName(key string) string {
+	return cleanPath(strings.TrimSuffix(strings.TrimPrefix(key, "files["), "]"))
+}
+
+// checkFiles validates the number of files in the request.
+func (s *Server) checkFiles(w http.ResponseWriter, keys []string) bool {
+	if len(keys) == 0 {
+		writeError(w, http.StatusBadRequest, codeNoFiles, "No files specified in request")
+		return false
+	}
+	if s.MaxFilesPerRequest > 0 && len(keys) > s.MaxFilesPerRequest {
+		writeError(w, http.StatusBadRequest, codeTooManyFiles, fmt.Sprintf("Too many files, max %v files per request", s.MaxFilesPerRequest))
+		return false
+	}
+	return true
+}
+
+func (s *Server) addFile(w http.ResponseWriter, files map[string][]byte) {
+
+	keys := fileNames(files)
+	if !s.checkFiles(w, keys) {
+		return
+	}
+
+	for _, key := range keys {
+		name := formName(key)
+		if _, ok := s.files[name]; ok {
+			writeError(w, http.StatusBadRequest, codeFileExists, "File with the same name already uploaded: "+name)
+			return
+		}
+		if dir := path.Dir(name); dir != "." && s.directories[dir] == nil {
+			writeError(w, http.StatusBadRequest, codeDirectoryNotFound, "Specified directory was not found: "+dir)
+			return
+		}
+	}
+
+	type stats struct {
+		FileID  int    `json:"file_id"`
+		Name    string `json:"name"`
+		Strings int    `json:"strings"`
+		Words   int    `json:"words"`
+	}
+	var result []stats
+
+	for _, key := range keys {
+		name := formName(key)
+		s.putFile(name, files[key])
+		result = append(result, stats{
+			FileID:  s.files[name].id,
+			Name:    name,
+			Strings: countStrings(files[key]),
+			Words:   countWords(files[key]),
+		})
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"success": true,
+		"stats":   map[string]interface{}{"files": result},
+	})
+}
+
+func (s *Server) updateFile(w http.ResponseWriter, files map[string][]byte) {
+
+	keys := fileNames(files)
+	if !s.checkFiles(w, keys) {
+		return
+	}
+
+	for _, key := range keys {
+		if _, ok := s.files[formName(key)]; !ok {
+			writeError(w, http.StatusNotFound, codeFileNotFound, "File was not found: "+formName(key))
+			return
+		}
+	}
+
+	result := make(map[string]string)
+	for _, key := range keys {
+		s.putFile(formName(key), files[key])
+		result[formName(key)] = "updated"
+	}
+
+	writeJSON(w, map[string]interface{}{"success": true, "files": result})
+}
+
+func (s *Server) deleteFile(w http.ResponseWriter, name string) {
+
+	name = cleanPath(name)
+	if _, ok := s.files[name]; !ok {
+		writeError(w, http.StatusNotFound, codeFileNotFound, "File was not found: "+name)
+		return
+	}
+
+	s.removeFile(name)
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+func (s *Server) removeFile(name string) {
+	delete(s.files, name)
+	for _, translations := range s.translations {
+		delete(translations, name)
+	}
+	s.changed = true
+}
+
+func (s *Server) uploadTranslation(w http.ResponseWriter, language string, files map[string][]byte) {
+
+	keys := fileNames(files)
+	if !s.checkFiles(w, keys) {
+		return
+	}
+
+	for _, key := range keys {
+		if _, ok := s.files[formName(key)]; !ok {
+			writeError(w, http.StatusNotFound, codeFileNotFound, "File was not found: "+formName(key))
+			return
+		}
+	}
+
+	type stats struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+	var result []stats
+
+	for _, key := range keys {
+		s.putTranslation(language, formName(key), files[key])
+		result = append(result, stats{Name: formName(key), Status: "uploaded"})
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"success": true,
+		"stats":   map[string]interface{}{"files": result},
+	})
+}
+
+// progress holds translation counters of a file, a directory or the whole project.
+type progress struct {
+	phrases, translated, words, wordsTranslated int
+}
+
+func (p *progress) add(other progress) {
+	p.phrases += other.phrases
+	p.translated += other.translated
+	p.words += other.words
+	p.wordsTranslated += other.wordsTranslated
+}
+
+// fields returns the counters in the format of language-status, numbers as strings.
+func (p progress) fields() map[string]interface{} {
+	return map[string]interface{}{
+		"phrases":          strconv.Itoa(p.phrases),
+		"translated":       strconv.Itoa(p.translated),
+		"approved":         "0",
+		"words":            strconv.Itoa(p.words),
+		"words_translated": strconv.Itoa(p.wordsTranslated),
+		"words_approved":   "0",
+	}
+}
+
+func (s *Server) fileProgress(language, name string) progress {
+	f := s.files[name]
+	p := progress{phrases: countStrings(f.content), words: countWords(f.content)}
+	if translation, ok := s.translations[language][name]; ok {
+		p.translated = min(countStrings(translation), p.phrases)
+		p.wordsTranslated = min(countWords(translation), p.words)
+	}
+	return p
+}
+
+func percent(part, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return part * 100 / total
+}
+
+func (s *Server) status(w http.ResponseWriter) {
+
+	var result []map[string]interface{}
+	for _, language := range s.Languages {
+		var total progress
+		for name := range s.files {
+			total.add(s.fileProgress(language, name))
+		}
+		result = append(result, map[string]interface{}{
+			"name":                languageName(language),
+			"code":                language,
+			"phrases":             strconv.Itoa(total.phrases),
+			"translated":          strconv.Itoa(total.translated),
+			"approved":            "0",
+			"words":               strconv.Itoa(total.words),
+			"words_translated":    strconv.Itoa(total.wordsTranslated),
+			"words_approved":      "0",
+			"translated_progress": percent(total.translated, total.phrases),
+			"approved_progress":   0,
+		})
+	}
+
+	writeJSON(w, result)
+}
+
+// tree returns the nodes of the directory in Crowdin format, directories first.
+// node returns the fields of a file, and progress of files is summed up for directories,
+// which get translation counters when stats is set.
+func (s *Server) tree(dir string, stats bool, node func(name string, f *file) (map[string]interface{}, progress)) ([]map[string]interface{}, progress) {
+
+	var dirs, files []string
+	for name := range s.directories {
+		if path.Dir(name) == dir {
+			dirs = append(dirs, name)
+		}
+	}
+	for name := range s.files {
+		if path.Dir(name) == dir {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+
+	var result []map[string]interface{}
+	var total progress
+
+	for _, name := range dirs {
+		children, p := s.tree(name, stats, node)
+		total.add(p)
+		fields := map[string]interface{}{
+			"id":        strconv.Itoa(s.directories[name].id),
+			"name":      path.Base(name),
+			"node_type": "directory",
+			"files":     children,
+		}
+		if stats {
+			for k, v := range p.fields() {
+				fields[k] = v
+			}
+		}
+		result = append(result, fields)
+	}
+
+	for _, name := range files {
+		fields, p := node(name, s.files[name])
+		total.add(p)
+		fields["id"] = strconv.Itoa(s.files[name].id)
+		fields["name"] = path.Base(name)
+		fields["node_type"] = "file"
+		result = append(result, fields)
+	}
+
+	return result, total
+}
+
+func (s *Server) languageStatus(w http.ResponseWriter, language string) {
+
+	files, _ := s.tree(".", true, func(name string, f *file) (map[string]interface{}, progress) {
+		p := s.fileProgress(language, name)
+		return p.fields(), p
+	})
+
+	writeJSON(w, map[string]interface{}{"files": files})
+}
+
+func (s *Server) info(w http.ResponseWriter) {
+
+	files, total := s.tree(".", false, func(name string, f *file) (map[string]interface{}, progress) {
+		return map[string]interface{}{
+			"created":       f.created.Format(timeLayout),
+			"last_updated":  f.updated.Format(timeLayout),
+			"last_accessed": "",
+			"last_revision": strconv.Itoa(f.revision),
+		}, progress{phrases: countStrings(f.content), words: countWords(f.content)}
+	})
+
+	var languages []map[string]interface{}
+	for _, language := range s.Languages {
+		languages = append(languages, map[string]interface{}{
+			"name":          languageName(language),
+			"code":          language,
+			"can_translate": 1,
+			"can_approve":   1,
+		})
+	}
+
+	lastBuild := ""
+	if !s.lastBuild.IsZero() {
+		lastBuild = s.lastBuild.Format(timeLayout)
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"languages": languages,
+		"files":     files,
+		"details": map[string]interface{}{
+			"source_language": map[string]interface{}{
+				"name": languageName(s.SourceLanguage),
+				"code": s.SourceLanguage,
+			},
+			"name":                    s.name,
+			"identifier":              s.Project,
+			"created":                 "",
+			"description":             "",
+			"join_policy":             "private",
+			"last_build":              lastBuild,
+			"last_activity":           "",
+			"participants_count":      "1",
+			"total_strings_count":     strconv.Itoa(total.phrases),
+			"total_words_count":       strconv.Itoa(total.words),
+			"duplicate_strings_count": 0,
+			"duplicate_words_count":   0,
+		},
+	})
+}
+
+func (s *Server) export(w http.ResponseWriter) {
+
+	status := "skipped"
+	if s.changed || s.lastBuild.IsZero() {
+		status = "built"
+		s.changed = false
+		s.lastBuild = time.Now().UTC()
+		s.pendingPolls = s.ExportPolls
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"success": map[string]interface{}{"status": status},
+	})
+}
+
+func (s *Server) exportStatus(w http.ResponseWriter) {
+
+	if s.pendingPolls > 0 && len(s.Languages) > 0 {
+		done := s.ExportPolls - s.pendingPolls
+		s.pendingPolls--
+		writeJSON(w, map[string]interface{}{
+			"status":           "in-progress",
+			"progress":         percent(done, s.ExportPolls),
+			"last_build":       "",
+			"current_language": s.Languages[done%len(s.Languages)],
+		})
+		return
+	}
+
+	lastBuild := ""
+	if !s.lastBuild.IsZero() {
+		lastBuild = s.lastBuild.Format(timeLayout)
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"status":     "finished",
+		"progress":   100,
+		"last_build": lastBuild,
+	})
+}
+
+// translation returns the translation of the file, or the source when it isn't translated.
+func (s *Server) translation(language, name string) []byte {
+	if content, ok := s.translations[language][name]; ok {
+		return content
+	}
+	return s.files[name].content
+}
+
+func (s *Server) download(w http.ResponseWriter, pkg string) {
+
+	languages := []string{pkg}
+	if pkg == "all" {
+		languages = s.Languages
+	} else if !contains(s.Languages, pkg) {
+		writeError(w, http.StatusNotFound, 0, "Language was not found: "+pkg)
+		return
+	}
+
+	var names []string
+	for name := range s.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, language := range languages {
+		for _, name := range names {
+			f, err := archive.Create(language + "/" + name)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, 0, err.Error())
+				return
+			}
+			f.Write(s.translation(language, name))
+		}
+	}
+	archive.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", strconv.Itoa(buffer.Len()))
+	w.Write(buffer.Bytes())
+}
+
+func (s *Server) exportFile(w http.ResponseWriter, name, language string) {
+
+	name = cleanPath(name)
+	if _, ok := s.files[name]; !ok {
+		writeError(w, http.StatusNotFound, codeFileNotFound, "File was not found: "+name)
+		return
+	}
+
+	w.Write(s.translation(language, name))
+}
+
+func (s *Server) addDirectory(w http.ResponseWriter, name string) {
+
+	name = cleanPath(name)
+	if s.directories[name] != nil {
+		writeError(w, http.StatusBadRequest, codeDirectoryExists, "Directory with such name already exists: "+name)
+		return
+	}
+	if dir := path.Dir(name); dir != "." && s.directories[dir] == nil {
+		writeError(w, http.StatusBadRequest, codeDirectoryNotFound, "Specified directory was not found: "+dir)
+		return
+	}
+
+	s.directories[name] = &directory{id: s.newID()}
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+func (s *Server) changeDirectory(w http.ResponseWriter, name, newName, title string) {
+
+	name = cleanPath(name)
+	dir := s.directories[name]
+	if dir == nil {
+		writeError(w, http.StatusNotFound, codeDirectoryNotFound, "Specified directory was not found: "+name)
+		return
+	}
+
+	if title != "" {
+		dir.title = title
+	}
+
+	if newName != "" {
+		target := path.Join(path.Dir(name), newName)
+		if s.directories[target] != nil {
+			writeError(w, http.StatusBadRequest, codeDirectoryExists, "Directory with such name already exists: "+target)
+			return
+		}
+		s.rename(name, target)
+	}
+
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+// rename moves the directory with all its content.
+func (s *Server) rename(from, to string) {
+	prefix := from + "/"
+	for name, dir := range s.directories {
+		if name == from || strings.HasPrefix(name, prefix) {
+			delete(s.directories, name)
+			s.directories[to+strings.TrimPrefix(name, from)] = dir
+		}
+	}
+	for name, f := range s.files {
+		if strings.HasPrefix(name, prefix) {
+			delete(s.files, name)
+			s.files[to+strings.TrimPrefix(name, from)] = f
+		}
+	}
+	for _, translations := range s.translations {
+		for name, content := range translations {
+			if strings.HasPrefix(name, prefix) {
+				delete(translations, name)
+				translations[to+strings.TrimPrefix(name, from)] = content
+			}
+		}
+	}
+	s.changed = true
+}
+
+func (s *Server) deleteDirectory(w http.ResponseWriter, name string) {
+
+	name = cleanPath(name)
+	if s.directories[name] == nil {
+		writeError(w, http.StatusNotFound, codeDirectoryNotFound, "Specified directory was not found: "+name)
+		return
+	}
+
+	prefix := name + "/"
+	for dir := range s.directories {
+		if dir == name || strings.HasPrefix(dir, prefix) {
+			delete(s.directories, dir)
+		}
+	}
+	for f := range s.files {
+		if strings.HasPrefix(f, prefix) {
+			s.removeFile(f)
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{"success": true})
+}
+
+func (s *Server) editProject(w http.ResponseWriter, name string, languages []string) {
+
+	if name != "" {
+		s.name = name
+	}
+	if languages != nil {
+		s.Languages = languages
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"project": map[string]interface{}{
+			"success": true,
+			"url":     "https://crowdin.com/project/" + s.Project,
+			"key":     s.Key,
+		},
+	})
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter) {
+
+	s.files = make(map[string]*file)
+	s.directories = make(map[string]*directory)
+	s.translations = make(map[string]map[string][]byte)
+	s.changed = true
+
+	writeJSON(w, map[string]interface{}{
+		"project": map[string]interface{}{"success": true},
+	})
+}
+
+func (s *Server) getProjects(w http.ResponseWriter) {
+
+	projects := []map[string]interface{}{{
+		"role":         "owner",
+		"name":         s.name,
+		"identifier":   s.Project,
+		"downloadable": 1,
+		"key":          s.Key,
+	}}
+	for _, p := range s.projects {
+		projects = append(projects, map[string]interface{}{
+			"role":         "owner",
+			"name":         p.name,
+			"identifier":   p.identifier,
+			"downloadable": 1,
+			"key":          p.key,
+		})
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"success":  true,
+		"projects": projects,
+	})
+}
+
+func (s *Server) createProject(w http.ResponseWriter, name, identifier string) {
+
+	if identifier == "" {
+		writeError(w, http.StatusBadRequest, 0, "Project identifier is required")
+		return
+	}
+
+	p := project{name: name, identifier: identifier, key: fmt.Sprintf("key-%v", s.newID())}
+	s.projects = append(s.projects, p)
+
+	writeJSON(w, map[string]interface{}{
+		"project": map[string]interface{}{
+			"success":    true,
+			"invitation": "https://crowdin.com/project/" + identifier + "/invite",
+			"url":        "https://crowdin.com/project/" + identifier,
+			"key":        p.key,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// countStrings counts non empty lines, good enough for csv and properties files.
+func countStrings(content []byte) int {
+	count := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func countWords(content []byte) int {
+	return len(strings.Fields(string(content)))
+}
+
+func languageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
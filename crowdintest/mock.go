@@ -0,0 +1,447 @@
+package crowdintest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+// ErrNotStubbed is returned by Mock calls without a stub function.
+var ErrNotStubbed = errors.New("crowdintest: call is not stubbed")
+
+// Mock is a stub implementation of crowdin.Client.
+// Calls are handled by the function field of the same name, e.g. AddFileFunc handles both AddFile and AddFileContext.
+// Calls without a function return ErrNotStubbed. All the calls are recorded.
+type Mock struct {
+	AddFileFunc                func(ctx context.Context, options *crowdin.AddFileOptions) (*crowdin.AddFileResult, error)
+	UpdateFileFunc             func(ctx context.Context, options *crowdin.UpdateFileOptions) (*crowdin.UpdateFileResult, error)
+	DeleteFileFunc             func(ctx context.Context, fileName string) (*crowdin.GeneralResult, error)
+	AddOrUpdateFileFunc        func(ctx context.Context, options *crowdin.AddOrUpdateFileOptions) (*crowdin.AddOrUpdateFileResult, error)
+	SyncFunc                   func(ctx context.Context, options *crowdin.SyncOptions) (*crowdin.SyncPlan, error)
+	SyncConfigFunc             func(ctx context.Context, config *crowdin.ResolvedConfig, options *crowdin.ConfigSyncOptions) (*crowdin.SyncPlan, error)
+	UploadTranslationsFunc     func(ctx context.Context, options *crowdin.UploadTranslationsOptions) (*crowdin.UploadTranslationResult, error)
+	GetTranslationsStatusFunc  func(ctx context.Context) ([]crowdin.TranslationStatus, error)
+	GetLanguageStatusFunc      func(ctx context.Context, languageCode string) (*crowdin.LanguageStatus, error)
+	ExportTranslationsFunc     func(ctx context.Context) (*crowdin.ExportTranslationsResult, error)
+	GetExportStatusFunc        func(ctx context.Context) (*crowdin.ExportStatus, error)
+	ExportAndWaitFunc          func(ctx context.Context, options *crowdin.ExportWaitOptions) (*crowdin.ExportResult, error)
+	DownloadTranslationsFunc   func(ctx context.Context, options *crowdin.DownloadOptions) error
+	DownloadTranslationsToFunc func(ctx context.Context, w io.Writer, options *crowdin.DownloadOptions) error
+	DownloadAndExtractFunc     func(ctx context.Context, options *crowdin.ExtractOptions) (*crowdin.ExtractResult, error)
+	DownloadConfigFunc         func(ctx context.Context, config *crowdin.ResolvedConfig, pkg string) (*crowdin.ExtractResult, error)
+	ExportFileFunc             func(ctx context.Context, options *crowdin.ExportFileOptions) error
+	ExportFileToFunc           func(ctx context.Context, w io.Writer, options *crowdin.ExportFileOptions) error
+	GetProjectDetailsFunc      func(ctx context.Context) (*crowdin.ProjectInfo, error)
+	EditProjectFunc            func(ctx context.Context, options *crowdin.EditProjectOptions) (*crowdin.ManageProjectResult, error)
+	DeleteProjectFunc          func(ctx context.Context) (*crowdin.DeleteProjectResult, error)
+	AddDirectoryFunc           func(ctx context.Context, directoryName string) (*crowdin.GeneralResult, error)
+	ChangeDirectoryFunc        func(ctx context.Context, options *crowdin.ChangeDirectoryOptions) (*crowdin.GeneralResult, error)
+	DeleteDirectoryFunc        func(ctx context.Context, directoryName string) (*crowdin.GeneralResult, error)
+	GetAccountProjectsFunc     func(ctx context.Context, accountKey, loginUsername string) (*crowdin.AccountDetails, error)
+	CreateProjectFunc          func(ctx context.Context, accountKey, loginUsername string, options *crowdin.CreateProjectOptions) (*crowdin.ManageProjectResult, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Call is a call received by Mock.
+type Call struct {
+	// Name of the method, without Context suffix, e.g. "AddFile".
+	Method string
+
+	// Arguments of the call, without the context.
+	Args []interface{}
+}
+
+var _ crowdin.Client = (*Mock)(nil)
+
+// Calls returns all the received calls, in order.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the received calls of the method, in order.
+func (m *Mock) CallsTo(method string) []Call {
+	var result []Call
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// AddFile calls AddFileFunc.
+func (m *Mock) AddFile(options *crowdin.AddFileOptions) (*crowdin.AddFileResult, error) {
+	return m.AddFileContext(context.Background(), options)
+}
+
+// AddFileContext calls AddFileFunc.
+func (m *Mock) AddFileContext(ctx context.Context, options *crowdin.AddFileOptions) (*crowdin.AddFileResult, error) {
+	m.record("AddFile", options)
+	if m.AddFileFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.AddFileFunc(ctx, options)
+}
+
+// UpdateFile calls UpdateFileFunc.
+func (m *Mock) UpdateFile(options *crowdin.UpdateFileOptions) (*crowdin.UpdateFileResult, error) {
+	return m.UpdateFileContext(context.Background(), options)
+}
+
+// UpdateFileContext calls UpdateFileFunc.
+func (m *Mock) UpdateFileContext(ctx context.Context, options *crowdin.UpdateFileOptions) (*crowdin.UpdateFileResult, error) {
+	m.record("UpdateFile", options)
+	if m.UpdateFileFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.UpdateFileFunc(ctx, options)
+}
+
+// DeleteFile calls DeleteFileFunc.
+func (m *Mock) DeleteFile(fileName string) (*crowdin.GeneralResult, error) {
+	return m.DeleteFileContext(context.Background(), fileName)
+}
+
+// DeleteFileContext calls DeleteFileFunc.
+func (m *Mock) DeleteFileContext(ctx context.Context, fileName string) (*crowdin.GeneralResult, error) {
+	m.record("DeleteFile", fileName)
+	if m.DeleteFileFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DeleteFileFunc(ctx, fileName)
+}
+
+// AddOrUpdateFile calls AddOrUpdateFileFunc.
+func (m *Mock) AddOrUpdateFile(options *crowdin.AddOrUpdateFileOptions) (*crowdin.AddOrUpdateFileResult, error) {
+	return m.AddOrUpdateFileContext(context.Background(), options)
+}
+
+// AddOrUpdateFileContext calls AddOrUpdateFileFunc.
+func (m *Mock) AddOrUpdateFileContext(ctx context.Context, options *crowdin.AddOrUpdateFileOptions) (*crowdin.AddOrUpdateFileResult, error) {
+	m.record("AddOrUpdateFile", options)
+	if m.AddOrUpdateFileFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.AddOrUpdateFileFunc(ctx, options)
+}
+
+// Sync calls SyncFunc.
+func (m *Mock) Sync(options *crowdin.SyncOptions) (*crowdin.SyncPlan, error) {
+	return m.SyncContext(context.Background(), options)
+}
+
+// SyncContext calls SyncFunc.
+func (m *Mock) SyncContext(ctx context.Context, options *crowdin.SyncOptions) (*crowdin.SyncPlan, error) {
+	m.record("Sync", options)
+	if m.SyncFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.SyncFunc(ctx, options)
+}
+
+// SyncConfig calls SyncConfigFunc.
+func (m *Mock) SyncConfig(config *crowdin.ResolvedConfig, options *crowdin.ConfigSyncOptions) (*crowdin.SyncPlan, error) {
+	return m.SyncConfigContext(context.Background(), config, options)
+}
+
+// SyncConfigContext calls SyncConfigFunc.
+func (m *Mock) SyncConfigContext(ctx context.Context, config *crowdin.ResolvedConfig, options *crowdin.ConfigSyncOptions) (*crowdin.SyncPlan, error) {
+	m.record("SyncConfig", config, options)
+	if m.SyncConfigFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.SyncConfigFunc(ctx, config, options)
+}
+
+// UploadTranslations calls UploadTranslationsFunc.
+func (m *Mock) UploadTranslations(options *crowdin.UploadTranslationsOptions) (*crowdin.UploadTranslationResult, error) {
+	return m.UploadTranslationsContext(context.Background(), options)
+}
+
+// UploadTranslationsContext calls UploadTranslationsFunc.
+func (m *Mock) UploadTranslationsContext(ctx context.Context, options *crowdin.UploadTranslationsOptions) (*crowdin.UploadTranslationResult, error) {
+	m.record("UploadTranslations", options)
+	if m.UploadTranslationsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.UploadTranslationsFunc(ctx, options)
+}
+
+// GetTranslationsStatus calls GetTranslationsStatusFunc.
+func (m *Mock) GetTranslationsStatus() ([]crowdin.TranslationStatus, error) {
+	return m.GetTranslationsStatusContext(context.Background())
+}
+
+// GetTranslationsStatusContext calls GetTranslationsStatusFunc.
+func (m *Mock) GetTranslationsStatusContext(ctx context.Context) ([]crowdin.TranslationStatus, error) {
+	m.record("GetTranslationsStatus")
+	if m.GetTranslationsStatusFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.GetTranslationsStatusFunc(ctx)
+}
+
+// GetLanguageStatus calls GetLanguageStatusFunc.
+func (m *Mock) GetLanguageStatus(languageCode string) (*crowdin.LanguageStatus, error) {
+	return m.GetLanguageStatusContext(context.Background(), languageCode)
+}
+
+// GetLanguageStatusContext calls GetLanguageStatusFunc.
+func (m *Mock) GetLanguageStatusContext(ctx context.Context, languageCode string) (*crowdin.LanguageStatus, error) {
+	m.record("GetLanguageStatus", languageCode)
+	if m.GetLanguageStatusFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.GetLanguageStatusFunc(ctx, languageCode)
+}
+
+// ExportTranslations calls ExportTranslationsFunc.
+func (m *Mock) ExportTranslations() (*crowdin.ExportTranslationsResult, error) {
+	return m.ExportTranslationsContext(context.Background())
+}
+
+// ExportTranslationsContext calls ExportTranslationsFunc.
+func (m *Mock) ExportTranslationsContext(ctx context.Context) (*crowdin.ExportTranslationsResult, error) {
+	m.record("ExportTranslations")
+	if m.ExportTranslationsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ExportTranslationsFunc(ctx)
+}
+
+// GetExportStatus calls GetExportStatusFunc.
+func (m *Mock) GetExportStatus() (*crowdin.ExportStatus, error) {
+	return m.GetExportStatusContext(context.Background())
+}
+
+// GetExportStatusContext calls GetExportStatusFunc.
+func (m *Mock) GetExportStatusContext(ctx context.Context) (*crowdin.ExportStatus, error) {
+	m.record("GetExportStatus")
+	if m.GetExportStatusFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.GetExportStatusFunc(ctx)
+}
+
+// ExportAndWait calls ExportAndWaitFunc.
+func (m *Mock) ExportAndWait(options *crowdin.ExportWaitOptions) (*crowdin.ExportResult, error) {
+	return m.ExportAndWaitContext(context.Background(), options)
+}
+
+// ExportAndWaitContext calls ExportAndWaitFunc.
+func (m *Mock) ExportAndWaitContext(ctx context.Context, options *crowdin.ExportWaitOptions) (*crowdin.ExportResult, error) {
+	m.record("ExportAndWait", options)
+	if m.ExportAndWaitFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ExportAndWaitFunc(ctx, options)
+}
+
+// DownloadTranslations calls DownloadTranslationsFunc.
+func (m *Mock) DownloadTranslations(options *crowdin.DownloadOptions) error {
+	return m.DownloadTranslationsContext(context.Background(), options)
+}
+
+// DownloadTranslationsContext calls DownloadTranslationsFunc.
+func (m *Mock) DownloadTranslationsContext(ctx context.Context, options *crowdin.DownloadOptions) error {
+	m.record("DownloadTranslations", options)
+	if m.DownloadTranslationsFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.DownloadTranslationsFunc(ctx, options)
+}
+
+// DownloadTranslationsTo calls DownloadTranslationsToFunc.
+func (m *Mock) DownloadTranslationsTo(w io.Writer, options *crowdin.DownloadOptions) error {
+	return m.DownloadTranslationsToContext(context.Background(), w, options)
+}
+
+// DownloadTranslationsToContext calls DownloadTranslationsToFunc.
+func (m *Mock) DownloadTranslationsToContext(ctx context.Context, w io.Writer, options *crowdin.DownloadOptions) error {
+	m.record("DownloadTranslationsTo", w, options)
+	if m.DownloadTranslationsToFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.DownloadTranslationsToFunc(ctx, w, options)
+}
+
+// DownloadAndExtract calls DownloadAndExtractFunc.
+func (m *Mock) DownloadAndExtract(options *crowdin.ExtractOptions) (*crowdin.ExtractResult, error) {
+	return m.DownloadAndExtractContext(context.Background(), options)
+}
+
+// DownloadAndExtractContext calls DownloadAndExtractFunc.
+func (m *Mock) DownloadAndExtractContext(ctx context.Context, options *crowdin.ExtractOptions) (*crowdin.ExtractResult, error) {
+	m.record("DownloadAndExtract", options)
+	if m.DownloadAndExtractFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DownloadAndExtractFunc(ctx, options)
+}
+
+// DownloadConfig calls DownloadConfigFunc.
+func (m *Mock) DownloadConfig(config *crowdin.ResolvedConfig, pkg string) (*crowdin.ExtractResult, error) {
+	return m.DownloadConfigContext(context.Background(), config, pkg)
+}
+
+// DownloadConfigContext calls DownloadConfigFunc.
+func (m *Mock) DownloadConfigContext(ctx context.Context, config *crowdin.ResolvedConfig, pkg string) (*crowdin.ExtractResult, error) {
+	m.record("DownloadConfig", config, pkg)
+	if m.DownloadConfigFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DownloadConfigFunc(ctx, config, pkg)
+}
+
+// ExportFile calls ExportFileFunc.
+func (m *Mock) ExportFile(options *crowdin.ExportFileOptions) error {
+	return m.ExportFileContext(context.Background(), options)
+}
+
+// ExportFileContext calls ExportFileFunc.
+func (m *Mock) ExportFileContext(ctx context.Context, options *crowdin.ExportFileOptions) error {
+	m.record("ExportFile", options)
+	if m.ExportFileFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.ExportFileFunc(ctx, options)
+}
+
+// ExportFileTo calls ExportFileToFunc.
+func (m *Mock) ExportFileTo(w io.Writer, options *crowdin.ExportFileOptions) error {
+	return m.ExportFileToContext(context.Background(), w, options)
+}
+
+// ExportFileToContext calls ExportFileToFunc.
+func (m *Mock) ExportFileToContext(ctx context.Context, w io.Writer, options *crowdin.ExportFileOptions) error {
+	m.record("ExportFileTo", w, options)
+	if m.ExportFileToFunc == nil {
+		return ErrNotStubbed
+	}
+	return m.ExportFileToFunc(ctx, w, options)
+}
+
+// GetProjectDetails calls GetProjectDetailsFunc.
+func (m *Mock) GetProjectDetails() (*crowdin.ProjectInfo, error) {
+	return m.GetProjectDetailsContext(context.Background())
+}
+
+// GetProjectDetailsContext calls GetProjectDetailsFunc.
+func (m *Mock) GetProjectDetailsContext(ctx context.Context) (*crowdin.ProjectInfo, error) {
+	m.record("GetProjectDetails")
+	if m.GetProjectDetailsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.GetProjectDetailsFunc(ctx)
+}
+
+// EditProject calls EditProjectFunc.
+func (m *Mock) EditProject(options *crowdin.EditProjectOptions) (*crowdin.ManageProjectResult, error) {
+	return m.EditProjectContext(context.Background(), options)
+}
+
+// EditProjectContext calls EditProjectFunc.
+func (m *Mock) EditProjectContext(ctx context.Context, options *crowdin.EditProjectOptions) (*crowdin.ManageProjectResult, error) {
+	m.record("EditProject", options)
+	if m.EditProjectFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.EditProjectFunc(ctx, options)
+}
+
+// DeleteProject calls DeleteProjectFunc.
+func (m *Mock) DeleteProject() (*crowdin.DeleteProjectResult, error) {
+	return m.DeleteProjectContext(context.Background())
+}
+
+// DeleteProjectContext calls DeleteProjectFunc.
+func (m *Mock) DeleteProjectContext(ctx context.Context) (*crowdin.DeleteProjectResult, error) {
+	m.record("DeleteProject")
+	if m.DeleteProjectFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DeleteProjectFunc(ctx)
+}
+
+// AddDirectory calls AddDirectoryFunc.
+func (m *Mock) AddDirectory(directoryName string) (*crowdin.GeneralResult, error) {
+	return m.AddDirectoryContext(context.Background(), directoryName)
+}
+
+// AddDirectoryContext calls AddDirectoryFunc.
+func (m *Mock) AddDirectoryContext(ctx context.Context, directoryName string) (*crowdin.GeneralResult, error) {
+	m.record("AddDirectory", directoryName)
+	if m.AddDirectoryFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.AddDirectoryFunc(ctx, directoryName)
+}
+
+// ChangeDirectory calls ChangeDirectoryFunc.
+func (m *Mock) ChangeDirectory(options *crowdin.ChangeDirectoryOptions) (*crowdin.GeneralResult, error) {
+	return m.ChangeDirectoryContext(context.Background(), options)
+}
+
+// ChangeDirectoryContext calls ChangeDirectoryFunc.
+func (m *Mock) ChangeDirectoryContext(ctx context.Context, options *crowdin.ChangeDirectoryOptions) (*crowdin.GeneralResult, error) {
+	m.record("ChangeDirectory", options)
+	if m.ChangeDirectoryFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.ChangeDirectoryFunc(ctx, options)
+}
+
+// DeleteDirectory calls DeleteDirectoryFunc.
+func (m *Mock) DeleteDirectory(directoryName string) (*crowdin.GeneralResult, error) {
+	return m.DeleteDirectoryContext(context.Background(), directoryName)
+}
+
+// DeleteDirectoryContext calls DeleteDirectoryFunc.
+func (m *Mock) DeleteDirectoryContext(ctx context.Context, directoryName string) (*crowdin.GeneralResult, error) {
+	m.record("DeleteDirectory", directoryName)
+	if m.DeleteDirectoryFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.DeleteDirectoryFunc(ctx, directoryName)
+}
+
+// GetAccountProjects calls GetAccountProjectsFunc.
+func (m *Mock) GetAccountProjects(accountKey, loginUsername string) (*crowdin.AccountDetails, error) {
+	return m.GetAccountProjectsContext(context.Background(), accountKey, loginUsername)
+}
+
+// GetAccountProjectsContext calls GetAccountProjectsFunc.
+func (m *Mock) GetAccountProjectsContext(ctx context.Context, accountKey, loginUsername string) (*crowdin.AccountDetails, error) {
+	m.record("GetAccountProjects", accountKey, loginUsername)
+	if m.GetAccountProjectsFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.GetAccountProjectsFunc(ctx, accountKey, loginUsername)
+}
+
+// CreateProject calls CreateProjectFunc.
+func (m *Mock) CreateProject(accountKey, loginUsername string, options *crowdin.CreateProjectOptions) (*crowdin.ManageProjectResult, error) {
+	return m.CreateProjectContext(context.Background(), accountKey, loginUsername, options)
+}
+
+// CreateProjectContext calls CreateProjectFunc.
+func (m *Mock) CreateProjectContext(ctx context.Context, accountKey, loginUsername string, options *crowdin.CreateProjectOptions) (*crowdin.ManageProjectResult, error) {
+	m.record("CreateProject", accountKey, loginUsername, options)
+	if m.CreateProjectFunc == nil {
+		return nil, ErrNotStubbed
+	}
+	return m.CreateProjectFunc(ctx, accountKey, loginUsername, options)
+}
@@ -0,0 +1,40 @@
+package crowdintest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	crowdin "github.com/medisafe/go-crowdin"
+	"github.com/medisafe/go-crowdin/crowdintest"
+)
+
+// removeDirectory is code under test, that depends on the interface.
+func removeDirectory(api crowdin.Client, name string) error {
+	_, err := api.DeleteDirectory(name)
+	if errors.Is(err, crowdin.ErrDirectoryNotFound) {
+		return nil
+	}
+	return err
+}
+
+func TestMock(t *testing.T) {
+	mock := &crowdintest.Mock{
+		DeleteDirectoryFunc: func(ctx context.Context, directoryName string) (*crowdin.GeneralResult, error) {
+			return nil, crowdin.APIError{StatusCode: 404, Endpoint: "delete-directory", Code: 17}
+		},
+	}
+
+	if err := removeDirectory(mock, "ui"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	calls := mock.CallsTo("DeleteDirectory")
+	if len(calls) != 1 || calls[0].Args[0] != "ui" {
+		t.Errorf("Unexpected calls %+v", calls)
+	}
+
+	if _, err := mock.AddFile(&crowdin.AddFileOptions{}); !errors.Is(err, crowdintest.ErrNotStubbed) {
+		t.Errorf("Expected %v, got %v", crowdintest.ErrNotStubbed, err)
+	}
+}
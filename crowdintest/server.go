@@ -0,0 +1,307 @@
+// Package crowdintest provides an in-memory fake of Crowdin API v1 for tests.
+//
+// The fake keeps the files, directories and translations of one project,
+// records every request it receives and can be told to fail requests:
+//
+//	server := crowdintest.NewServer()
+//	defer server.Close()
+//
+//	server.InjectFault(crowdintest.Fault{Endpoint: "add-file", StatusCode: 503})
+//	api := server.Client()
+package crowdintest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	crowdin "github.com/medisafe/go-crowdin"
+)
+
+// Server is a fake Crowdin API server.
+// Exported fields should be set before the first request is sent.
+type Server struct {
+	*httptest.Server
+
+	// Identifier and API key of the project.
+	Project string
+	Key     string
+
+	// Key of the account that owns the project, used by account API.
+	AccountKey string
+
+	// Source and target languages of the project.
+	SourceLanguage string
+	Languages      []string
+
+	// Max number of files in one add-file, update-file or upload-translation request. Zero means no limit.
+	MaxFilesPerRequest int
+
+	// Number of export-status calls that report the export in progress after each build.
+	ExportPolls int
+
+	mu           sync.Mutex
+	name         string
+	nextID       int
+	files        map[string]*file
+	directories  map[string]*directory
+	translations map[string]map[string][]byte
+	projects     []project
+	changed      bool
+	lastBuild    time.Time
+	pendingPolls int
+	faults       []*Fault
+	requests     []Request
+}
+
+type file struct {
+	id       int
+	content  []byte
+	created  time.Time
+	updated  time.Time
+	revision int
+}
+
+type project struct {
+	name       string
+	identifier string
+	key        string
+}
+
+type directory struct {
+	id    int
+	title string
+}
+
+// Fault makes the server fail requests instead of handling them.
+type Fault struct {
+	// Endpoint of the failed requests, e.g. "add-file". Empty matches all the endpoints.
+	Endpoint string
+
+	// Response of the failed requests. Code and Message are sent as Crowdin error payload when Code isn't zero.
+	StatusCode int
+	Code       int
+	Message    string
+	Header     http.Header
+
+	// Delay before the response is sent.
+	Delay time.Duration
+
+	// Number of requests to fail. Zero fails only the next one, negative fails all of them.
+	Times int
+}
+
+// Request is a request received by the server.
+type Request struct {
+	// Endpoint of the request, e.g. "add-file" or "download".
+	Endpoint string
+	Method   string
+	Header   http.Header
+
+	// Query and form params, without the API key.
+	Params url.Values
+
+	// Content of the uploaded files, by form key, e.g. "files[strings.csv]".
+	Files map[string][]byte
+}
+
+// NewServer starts a fake server of project "test-project" with key "test-key",
+// source language "en" and target languages "ru" and "de".
+func NewServer() *Server {
+
+	s := &Server{
+		Project:        "test-project",
+		Key:            "test-key",
+		AccountKey:     "test-account-key",
+		SourceLanguage: "en",
+		Languages:      []string{"ru", "de"},
+		name:           "Test Project",
+		files:          make(map[string]*file),
+		directories:    make(map[string]*directory),
+		translations:   make(map[string]map[string][]byte),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client of the server's project.
+func (s *Server) Client(options ...crowdin.Option) *crowdin.Crowdin {
+	options = append([]crowdin.Option{
+		crowdin.WithBaseURL(s.URL + "/api/project/"),
+		crowdin.WithAccountBaseURL(s.URL + "/api/account/"),
+		crowdin.WithHTTPClient(s.Server.Client()),
+	}, options...)
+	return crowdin.NewWithOptions(s.Key, s.Project, options...)
+}
+
+// InjectFault makes the server fail the next requests matching the fault.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// Requests returns all the received requests, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the received requests of the endpoint, in order.
+func (s *Server) RequestsTo(endpoint string) []Request {
+	var result []Request
+	for _, req := range s.Requests() {
+		if req.Endpoint == endpoint {
+			result = append(result, req)
+		}
+	}
+	return result
+}
+
+// AddFile adds a source file to the project. Missing parent directories are created.
+func (s *Server) AddFile(name string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name = cleanPath(name)
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if s.directories[dir] == nil {
+			s.directories[dir] = &directory{id: s.newID()}
+		}
+	}
+	s.putFile(name, content)
+}
+
+// AddDirectory adds a directory to the project. Missing parent directories are created.
+func (s *Server) AddDirectory(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for dir := cleanPath(name); dir != "."; dir = path.Dir(dir) {
+		if s.directories[dir] == nil {
+			s.directories[dir] = &directory{id: s.newID()}
+		}
+	}
+	s.changed = true
+}
+
+// SetTranslation sets the translation of the file to the language.
+func (s *Server) SetTranslation(language, name string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putTranslation(language, cleanPath(name), content)
+}
+
+// File returns the content of the source file.
+func (s *Server) File(name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[cleanPath(name)]
+	if !ok {
+		return nil, false
+	}
+	return f.content, true
+}
+
+// Translation returns the translation of the file to the language.
+func (s *Server) Translation(language, name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.translations[language][cleanPath(name)]
+	return content, ok
+}
+
+// Files returns paths of all the source files, sorted.
+func (s *Server) Files() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for name := range s.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Directories returns paths of all the directories, sorted.
+func (s *Server) Directories() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var names []string
+	for name := range s.directories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LastBuild returns the time of the last export that built translations.
+func (s *Server) LastBuild() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastBuild
+}
+
+func (s *Server) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) putFile(name string, content []byte) {
+	now := time.Now().UTC()
+	f, ok := s.files[name]
+	if !ok {
+		f = &file{id: s.newID(), created: now}
+		s.files[name] = f
+	}
+	f.content = content
+	f.updated = now
+	f.revision++
+	s.changed = true
+}
+
+func (s *Server) putTranslation(language, name string, content []byte) {
+	if s.translations[language] == nil {
+		s.translations[language] = make(map[string][]byte)
+	}
+	s.translations[language][name] = content
+	s.changed = true
+}
+
+// fault returns the first fault matching the endpoint and counts it down.
+func (s *Server) fault(endpoint string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+		matched := *f
+		if f.Times >= 0 {
+			f.Times--
+			if f.Times <= 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &matched
+	}
+
+	return nil
+}
+
+// cleanPath normalizes Crowdin paths, which may start with slash, to "dir/file.csv".
+func cleanPath(name string) string {
+	return path.Clean(strings.TrimPrefix(path.Clean("/"+name), "/"))
+}
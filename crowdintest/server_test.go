@@ -0,0 +1,428 @@
+package crowdintest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	crowdin "github.com/medisafe/go-crowdin"
+	"github.com/medisafe/go-crowdin/crowdintest"
+)
+
+func TestServer_files(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	api := server.Client()
+
+	local := filepath.Join(t.TempDir(), "menu.csv")
+	os.WriteFile(local, []byte("play,Play\nquit,Quit\n"), 0644)
+
+	if _, err := api.AddDirectory("ui"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := api.AddDirectory("ui"); !errors.Is(err, crowdin.ErrDirectoryExists) {
+		t.Errorf("Expected %v, got %v", crowdin.ErrDirectoryExists, err)
+	}
+
+	result, err := api.AddFile(&crowdin.AddFileOptions{Files: map[string]string{"ui/menu.csv": local}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Stats.Files) != 1 || result.Stats.Files[0].Strings != 2 {
+		t.Errorf("Unexpected stats %+v", result.Stats)
+	}
+
+	if _, err := api.AddFile(&crowdin.AddFileOptions{Files: map[string]string{"ui/menu.csv": local}}); !errors.Is(err, crowdin.ErrFileExists) {
+		t.Errorf("Expected %v, got %v", crowdin.ErrFileExists, err)
+	}
+	if _, err := api.UpdateFile(&crowdin.UpdateFileOptions{Files: map[string]string{"missing.csv": local}}); !errors.Is(err, crowdin.ErrFileNotFound) {
+		t.Errorf("Expected %v, got %v", crowdin.ErrFileNotFound, err)
+	}
+
+	_, err = api.UploadTranslations(&crowdin.UploadTranslationsOptions{
+		Language: "ru",
+		Sources: map[string]crowdin.FileSource{
+			"ui/menu.csv": crowdin.FromReader("menu.csv", bytes.NewReader([]byte("play,Играть\n"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	status, err := api.GetLanguageStatus("ru")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(status.Files) != 1 || status.Files[0].Name != "ui" || status.Files[0].Translated != 1 {
+		t.Errorf("Unexpected language status %+v", status.Files)
+	}
+
+	info, err := api.GetProjectDetails()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info.Details.Identifier != server.Project || info.Details.TotalStringsCount != 2 {
+		t.Errorf("Unexpected details %+v", info.Details)
+	}
+
+	if got := server.Files(); len(got) != 1 || got[0] != "ui/menu.csv" {
+		t.Errorf("Expected %v, got %v", []string{"ui/menu.csv"}, got)
+	}
+	if got := server.RequestsTo("add-file"); len(got) != 2 || got[0].Files["files[ui/menu.csv]"] == nil {
+		t.Errorf("Unexpected add-file requests %+v", got)
+	}
+}
+
+func TestServer_export(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	server.AddFile("ui/menu.csv", []byte("play,Play\n"))
+	server.SetTranslation("ru", "ui/menu.csv", []byte("play,Играть\n"))
+
+	api := server.Client()
+
+	for _, want := range []crowdin.BuildStatus{crowdin.ExportBuilt, crowdin.ExportSkipped} {
+		result, err := api.ExportTranslations()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Success.Status != want {
+			t.Errorf("Expected %v, got %v", want, result.Success.Status)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := api.DownloadTranslationsTo(&buffer, &crowdin.DownloadOptions{Package: "all"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("Expected zip, got %v", err)
+	}
+	contents := map[string]string{}
+	for _, f := range archive.File {
+		r, _ := f.Open()
+		content, _ := io.ReadAll(r)
+		contents[f.Name] = string(content)
+	}
+	if contents["ru/ui/menu.csv"] != "play,Играть\n" || contents["de/ui/menu.csv"] != "play,Play\n" {
+		t.Errorf("Unexpected archive %v", contents)
+	}
+}
+
+func TestServer_InjectFault(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	server.InjectFault(crowdintest.Fault{
+		Endpoint:   "status",
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"0"}},
+		Times:      2,
+	})
+
+	api := server.Client()
+	api.SetRetryPolicy(&crowdin.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	if _, err := api.GetTranslationsStatus(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if got := len(server.RequestsTo("status")); got != 3 {
+		t.Errorf("Expected %v, got %v", 3, got)
+	}
+
+	server.InjectFault(crowdintest.Fault{Endpoint: "add-directory", StatusCode: http.StatusBadRequest, Code: 3, Message: "API key is not valid"})
+	if _, err := api.AddDirectory("ui"); !errors.Is(err, crowdin.ErrInvalidKey) {
+		t.Errorf("Expected %v, got %v", crowdin.ErrInvalidKey, err)
+	}
+}
+
+func TestServer_maxFilesPerRequest(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+	server.MaxFilesPerRequest = 2
+
+	sources := make(map[string]crowdin.FileSource)
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("strings_%v.csv", i)
+		sources[name] = crowdin.FromReader(name, bytes.NewReader([]byte("play,Play\n")))
+	}
+
+	api := server.Client(crowdin.WithBatchSize(2))
+	result, err := api.AddFile(&crowdin.AddFileOptions{Sources: sources})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Stats.Files) != 5 {
+		t.Errorf("Expected %v, got %v", 5, len(result.Stats.Files))
+	}
+	if requests := server.RequestsTo("add-file"); len(requests) != 3 {
+		t.Errorf("Expected %v, got %v", 3, len(requests))
+	}
+
+	updated, err := api.UpdateFile(&crowdin.UpdateFileOptions{Sources: sources})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updated.Files) != 5 {
+		t.Errorf("Expected %v, got %v", 5, len(updated.Files))
+	}
+}
+
+func TestServer_addOrUpdateFile(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	server.AddFile("ui/menu.csv", []byte("play,Play\n"))
+
+	api := server.Client()
+	result, err := api.AddOrUpdateFile(&crowdin.AddOrUpdateFileOptions{
+		Type: crowdin.FileTypeCSV,
+		Sources: map[string]crowdin.FileSource{
+			"ui/menu.csv":             crowdin.FromReader("menu.csv", bytes.NewReader([]byte("play,Play game\n"))),
+			"/ui/dialogs/quit.csv":    crowdin.FromReader("quit.csv", bytes.NewReader([]byte("quit,Quit\n"))),
+			"store/items/potions.csv": crowdin.FromReader("potions.csv", bytes.NewReader([]byte("heal,Heal\n"))),
+			"store/items/weapons.csv": crowdin.FromReader("weapons.csv", bytes.NewReader([]byte("sword,Sword\n"))),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantDirs := []string{"store", "store/items", "ui/dialogs"}
+	if fmt.Sprint(result.Directories) != fmt.Sprint(wantDirs) {
+		t.Errorf("Expected %v, got %v", wantDirs, result.Directories)
+	}
+	if result.Added == nil || len(result.Added.Stats.Files) != 3 {
+		t.Errorf("Expected 3 added files, got %+v", result.Added)
+	}
+	if result.Updated == nil || len(result.Updated.Files) != 1 {
+		t.Errorf("Expected 1 updated file, got %+v", result.Updated)
+	}
+
+	if content, _ := server.File("ui/menu.csv"); string(content) != "play,Play game\n" {
+		t.Errorf("Expected %q, got %q", "play,Play game\n", content)
+	}
+	if _, ok := server.File("store/items/weapons.csv"); !ok {
+		t.Errorf("Expected store/items/weapons.csv to be added")
+	}
+}
+
+func TestServer_sync(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	server.AddFile("en/ui/menu.csv", []byte("play,Play\n"))
+	server.AddFile("en/ui/old.csv", []byte("old,Old\n"))
+	server.AddFile("en/legacy/help.csv", []byte("help,Help\n"))
+	server.AddFile("en/drafts/store.csv", []byte("buy,Buy\n"))
+	server.AddFile("en/stable.csv", []byte("ok,OK\n"))
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		local := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(local), 0o755)
+		if err := os.WriteFile(local, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("ui/menu.csv", "play,Play game\n")
+	write("ui/dialogs/quit.csv", "quit,Quit\n")
+	write("stable.csv", "ok,OK\n")
+	write("notes.txt", "not synced")
+	past := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(dir, "stable.csv"), past, past)
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(dir, "ui", "menu.csv"), future, future)
+
+	api := server.Client()
+	options := &crowdin.SyncOptions{
+		LocalPath: dir,
+		Root:      "en",
+		Include:   []string{"**/*.csv"},
+		Exclude:   []string{"drafts/**"},
+		Delete:    true,
+		DryRun:    true,
+	}
+
+	plan, err := api.Sync(options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := "add-directory     en/ui/dialogs\n" +
+		"add-file          en/ui/dialogs/quit.csv\n" +
+		"update-unverified en/stable.csv\n" +
+		"update-unverified en/ui/menu.csv\n" +
+		"delete-file       en/legacy/help.csv\n" +
+		"delete-file       en/ui/old.csv\n" +
+		"delete-directory  en/legacy\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+	if len(server.RequestsTo("add-file")) != 0 {
+		t.Errorf("Expected no changes in dry run")
+	}
+
+	// only files with a later local time are updated
+	options.ModifiedOnly = true
+	plan, err = api.Sync(options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated := plan.Paths(crowdin.SyncUpdateFile); len(updated) != 1 || updated[0] != "en/ui/menu.csv" {
+		t.Errorf("Expected %v, got %v", []string{"en/ui/menu.csv"}, updated)
+	}
+	if unverified := plan.Paths(crowdin.SyncUpdateUnverified); len(unverified) != 0 {
+		t.Errorf("Expected no unverified updates, got %v", unverified)
+	}
+	options.ModifiedOnly = false
+
+	options.DryRun = false
+	if _, err := api.Sync(options); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wantFiles := "[en/drafts/store.csv en/stable.csv en/ui/dialogs/quit.csv en/ui/menu.csv]"
+	if got := fmt.Sprint(server.Files()); got != wantFiles {
+		t.Errorf("Expected %v, got %v", wantFiles, got)
+	}
+	if content, _ := server.File("en/ui/menu.csv"); string(content) != "play,Play game\n" {
+		t.Errorf("Expected %q, got %q", "play,Play game\n", content)
+	}
+	if got := fmt.Sprint(server.Directories()); got != "[en en/drafts en/ui en/ui/dialogs]" {
+		t.Errorf("Unexpected directories %v", got)
+	}
+
+	// a change that keeps an older time, e.g. after git checkout, is uploaded too
+	write("stable.csv", "ok,OK v2\n")
+	os.Chtimes(filepath.Join(dir, "stable.csv"), past, past)
+	if _, err := api.Sync(options); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if content, _ := server.File("en/stable.csv"); string(content) != "ok,OK v2\n" {
+		t.Errorf("Expected %q, got %q", "ok,OK v2\n", content)
+	}
+}
+
+func TestServer_downloadAndExtract(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	server.AddFile("ui/strings.csv", []byte("play,Play\n"))
+	server.SetTranslation("ru", "ui/strings.csv", []byte("play,Играть\n"))
+
+	dir := t.TempDir()
+	result, err := server.Client().DownloadAndExtract(&crowdin.ExtractOptions{
+		Package:   "all",
+		LocalPath: dir,
+		Pattern:   "res/values-%android_code%/%file_name%.csv",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("Expected %v, got %v", 2, len(result.Files))
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "res", "values-ru-rRU", "strings.csv"))
+	if err != nil || string(content) != "play,Играть\n" {
+		t.Errorf("Expected %q, got %q (%v)", "play,Играть\n", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "res", "values-de-rDE", "strings.csv")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestServer_config(t *testing.T) {
+	server := crowdintest.NewServer()
+	defer server.Close()
+
+	dir := t.TempDir()
+	for _, name := range []string{"locale/en/ui/menu.csv", "ios/en.lproj/Localizable.strings"} {
+		local := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(local), 0755)
+		os.WriteFile(local, []byte("play,Play\n"), 0644)
+		os.Chtimes(local, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))
+	}
+
+	file, err := crowdin.ParseConfig([]byte(`
+files:
+  - source: /locale/en/**/*.csv
+    translation: /locale/%two_letters_code%/**/%original_file_name%
+    type: csv
+    scheme: identifier,source_phrase
+  - source: /ios/en.lproj/*.strings
+    translation: /ios/%osx_code%/%original_file_name%
+    dest: /ios/%original_file_name%
+`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	config, err := file.Resolve(dir, os.Getenv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	api := server.Client()
+	plan, err := api.SyncConfig(config, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if added := plan.Paths(crowdin.SyncAddFile); len(added) != 2 {
+		t.Errorf("Expected %v, got %v", 2, added)
+	}
+
+	if _, ok := server.File("ios/Localizable.strings"); !ok {
+		t.Errorf("Expected %v, got %v", "ios/Localizable.strings", server.Files())
+	}
+
+	// a call per group, with the type and scheme of the group
+	requests := server.RequestsTo("add-file")
+	if len(requests) != 2 {
+		t.Fatalf("Expected %v, got %v", 2, len(requests))
+	}
+	for _, request := range requests {
+		want := ""
+		if _, ok := request.Files["files[menu.csv]"]; ok {
+			want = "identifier,source_phrase"
+		}
+		if scheme := request.Params.Get("scheme"); scheme != want {
+			t.Errorf("Expected %q, got %q", want, scheme)
+		}
+	}
+
+	plan, err = api.SyncConfig(config, &crowdin.ConfigSyncOptions{ModifiedOnly: true, DryRun: true})
+	if err != nil || !plan.Empty() {
+		t.Errorf("Expected empty plan, got %v (%v)", plan, err)
+	}
+
+	server.SetTranslation("ru", "menu.csv", []byte("play,Играть\n"))
+
+	result, err := api.DownloadConfig(config, "ru")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Files) != 2 {
+		t.Errorf("Expected %v, got %v", 2, len(result.Files))
+	}
+
+	content, err := os.ReadFile(config.Files[0].TranslationPath("ru"))
+	if err != nil || string(content) != "play,Играть\n" {
+		t.Errorf("Expected %q, got %q (%v)", "play,Играть\n", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ios", "ru.lproj", "Localizable.strings")); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
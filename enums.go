@@ -0,0 +1,182 @@
+package crowdin
+
+import "strings"
+
+// FileType is the format of a source file, see AddFileOptions.Type.
+type FileType string
+
+// File types supported by Crowdin.
+const (
+	// FileTypeAuto - Try to detect file type by extension or MIME type.
+	FileTypeAuto FileType = "auto"
+	// FileTypeGettext - GNU GetText (*.po, *.pot)
+	FileTypeGettext FileType = "gettext"
+	// FileTypeQtTS - Nokia Qt (*.ts)
+	FileTypeQtTS FileType = "qtts"
+	// FileTypeDKLang - Delphi DKLang (*.dklang)
+	FileTypeDKLang FileType = "dklang"
+	// FileTypeAndroid - Android (*.xml)
+	FileTypeAndroid FileType = "android"
+	// FileTypeResX - .NET (*.resx)
+	FileTypeResX FileType = "resx"
+	// FileTypeProperties - Java (*.properties)
+	FileTypeProperties FileType = "properties"
+	// FileTypeMacOSX - Mac OS X / iOS (*.strings)
+	FileTypeMacOSX FileType = "macosx"
+	// FileTypeBlackBerry - BlackBerry (*.rrc)
+	FileTypeBlackBerry FileType = "blackberry"
+	// FileTypeSymbian - Symbian (*.lXX)
+	FileTypeSymbian FileType = "symbian"
+	// FileTypeFlex - Adobe Flex (*.properties)
+	FileTypeFlex FileType = "flex"
+	// FileTypeBada - Samsung Bada (*.xml)
+	FileTypeBada FileType = "bada"
+	// FileTypeTXT - Plain Text (*.txt)
+	FileTypeTXT FileType = "txt"
+	// FileTypeSRT - SubRip .srt (*.srt)
+	FileTypeSRT FileType = "srt"
+	// FileTypeSBV - Youtube .sbv (*.sbv)
+	FileTypeSBV FileType = "sbv"
+	// FileTypeXLIFF - XLIFF (*.xliff)
+	FileTypeXLIFF FileType = "xliff"
+	// FileTypeHTML - HTML (*.html, *.htm, *.xhtml, *.xhtm)
+	FileTypeHTML FileType = "html"
+	// FileTypeDTD - Mozilla DTD (*.dtd)
+	FileTypeDTD FileType = "dtd"
+	// FileTypeChrome - Google Chrome Extension (*.json)
+	FileTypeChrome FileType = "chrome"
+	// FileTypeYAML - Ruby On Rails (*.yaml)
+	FileTypeYAML FileType = "yaml"
+	// FileTypeCSV - Comma Separated Values (*.csv), see Scheme.
+	FileTypeCSV FileType = "csv"
+	// FileTypeRC - Windows Resources (*.rc)
+	FileTypeRC FileType = "rc"
+	// FileTypeWXL - WiX Installer Resources (*.wxl)
+	FileTypeWXL FileType = "wxl"
+	// FileTypeNSH - NSIS Installer Resources (*.nsh)
+	FileTypeNSH FileType = "nsh"
+	// FileTypeJoomla - Joomla localizable resources (*.ini)
+	FileTypeJoomla FileType = "joomla"
+	// FileTypeINI - Generic INI (*.ini)
+	FileTypeINI FileType = "ini"
+	// FileTypeISL - ISL (*.isl)
+	FileTypeISL FileType = "isl"
+	// FileTypeResW - Windows 8 Metro (*.resw)
+	FileTypeResW FileType = "resw"
+	// FileTypeResJSON - Windows 8 Metro (*.resjson)
+	FileTypeResJSON FileType = "resjson"
+	// FileTypeDocX - Microsoft Office and OpenOffice.org Documents (*.docx, *.dotx, *.odt, *.ott, *.xslx, *.xltx, *.pptx, *.potx, *.ods, *.ots, *.odg, *.otg, *.odp, *.otp, *.idml)
+	FileTypeDocX FileType = "docx"
+	// FileTypeMarkdown - Markdown (*.md, *.text, *.markdown...)
+	FileTypeMarkdown FileType = "md"
+	// FileTypeMediaWiki - MediaWiki (*.wiki, *.wikitext, *.mediawiki)
+	FileTypeMediaWiki FileType = "mediawiki"
+	// FileTypePlay - Play Framework
+	FileTypePlay FileType = "play"
+	// FileTypeHaml - Haml (*.haml)
+	FileTypeHaml FileType = "haml"
+	// FileTypeARB - Application Resource Bundle (*.arb)
+	FileTypeARB FileType = "arb"
+	// FileTypeVTT - Video Subtitling and WebVTT (*.vtt)
+	FileTypeVTT FileType = "vtt"
+)
+
+var fileTypes = map[FileType]bool{
+	FileTypeAuto: true, FileTypeGettext: true, FileTypeQtTS: true, FileTypeDKLang: true,
+	FileTypeAndroid: true, FileTypeResX: true, FileTypeProperties: true, FileTypeMacOSX: true,
+	FileTypeBlackBerry: true, FileTypeSymbian: true, FileTypeFlex: true, FileTypeBada: true,
+	FileTypeTXT: true, FileTypeSRT: true, FileTypeSBV: true, FileTypeXLIFF: true,
+	FileTypeHTML: true, FileTypeDTD: true, FileTypeChrome: true, FileTypeYAML: true,
+	FileTypeCSV: true, FileTypeRC: true, FileTypeWXL: true, FileTypeNSH: true,
+	FileTypeJoomla: true, FileTypeINI: true, FileTypeISL: true, FileTypeResW: true,
+	FileTypeResJSON: true, FileTypeDocX: true, FileTypeMarkdown: true, FileTypeMediaWiki: true,
+	FileTypePlay: true, FileTypeHaml: true, FileTypeARB: true, FileTypeVTT: true,
+}
+
+// Valid reports whether the file type is known to Crowdin. Empty type means auto detection.
+func (t FileType) Valid() bool {
+	return t == "" || fileTypes[FileType(strings.ToLower(string(t)))]
+}
+
+// SchemeColumn is a data column of a CSV (or XLS/XLSX) file, see Scheme.
+type SchemeColumn string
+
+// Columns of CSV files.
+const (
+	// SchemeIdentifier - Column contains string identifier.
+	SchemeIdentifier SchemeColumn = "identifier"
+	// SchemeSourcePhrase - Column contains only source string (in result string will contain same string).
+	SchemeSourcePhrase SchemeColumn = "source_phrase"
+	// SchemeSourceOrTranslation - Column contains source string but when exporting same column should contain translation
+	// (also when uploading existing translations, the value from this column will be used as a translated string).
+	SchemeSourceOrTranslation SchemeColumn = "source_or_translation"
+	// SchemeTranslation - Column contains translated string (when imported file already contains translations).
+	SchemeTranslation SchemeColumn = "translation"
+	// SchemeContext - Column contains some comments on source string. Context information.
+	SchemeContext SchemeColumn = "context"
+	// SchemeMaxLength - Column contains max. length of translation for this string.
+	SchemeMaxLength SchemeColumn = "max_length"
+	// SchemeNone - Do not import column.
+	SchemeNone SchemeColumn = "none"
+)
+
+var schemeColumns = map[SchemeColumn]bool{
+	SchemeIdentifier:          true,
+	SchemeSourcePhrase:        true,
+	SchemeSourceOrTranslation: true,
+	SchemeTranslation:         true,
+	SchemeContext:             true,
+	SchemeMaxLength:           true,
+	SchemeNone:                true,
+}
+
+// Valid reports whether the column is known to Crowdin.
+func (c SchemeColumn) Valid() bool {
+	return schemeColumns[c]
+}
+
+// Scheme is the mapping of data columns of a CSV (or XLS/XLSX) file, e.g. "identifier,source_phrase,context".
+type Scheme string
+
+// NewScheme - Build the scheme from the columns, in the order of the file.
+func NewScheme(columns ...SchemeColumn) Scheme {
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = string(column)
+	}
+
+	return Scheme(strings.Join(names, ","))
+}
+
+// Columns returns the columns of the scheme, in the order of the file.
+func (s Scheme) Columns() []SchemeColumn {
+
+	if s == "" {
+		return nil
+	}
+
+	names := strings.Split(string(s), ",")
+	columns := make([]SchemeColumn, len(names))
+	for i, name := range names {
+		columns[i] = SchemeColumn(strings.TrimSpace(name))
+	}
+
+	return columns
+}
+
+// JoinPolicy defines who can join the project.
+type JoinPolicy string
+
+// Join policies of projects.
+const (
+	// JoinPolicyOpen - Anyone can join the project.
+	JoinPolicyOpen JoinPolicy = "open"
+	// JoinPolicyPrivate - Only invited users can join the project.
+	JoinPolicyPrivate JoinPolicy = "private"
+)
+
+// Valid reports whether the join policy is known to Crowdin. Empty policy keeps the default.
+func (p JoinPolicy) Valid() bool {
+	return p == "" || p == JoinPolicyOpen || p == JoinPolicyPrivate
+}
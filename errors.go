@@ -0,0 +1,116 @@
+package crowdin
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors that can be matched with errors.Is against the errors returned by API calls.
+var (
+	// ErrInvalidKey - API key is not valid or the project doesn't exist.
+	ErrInvalidKey = errors.New("crowdin: invalid API key")
+
+	// ErrFileNotFound - File with given name doesn't exist in the project.
+	ErrFileNotFound = errors.New("crowdin: file not found")
+
+	// ErrFileExists - File with the same name is already uploaded.
+	ErrFileExists = errors.New("crowdin: file already exists")
+
+	// ErrDirectoryNotFound - Directory with given name doesn't exist in the project.
+	ErrDirectoryNotFound = errors.New("crowdin: directory not found")
+
+	// ErrDirectoryExists - Directory with the same name already exists.
+	ErrDirectoryExists = errors.New("crowdin: directory already exists")
+
+	// ErrExportThrottled - Translations were exported recently and can't be exported again yet.
+	ErrExportThrottled = errors.New("crowdin: export throttled")
+)
+
+// errorCodes maps error codes of Crowdin API v1 to the sentinel errors.
+var errorCodes = map[int]error{
+	3:  ErrInvalidKey,
+	5:  ErrFileExists,
+	8:  ErrFileNotFound,
+	17: ErrDirectoryNotFound,
+	50: ErrDirectoryExists,
+}
+
+// APIError holds data of errors returned from the API.
+type APIError struct {
+	// HTTP status code of the response.
+	StatusCode int
+
+	// Endpoint of the failed call, e.g. "add-file".
+	Endpoint string
+
+	// Error code and message from the response payload. Empty when the payload isn't a Crowdin error.
+	Code    int
+	Message string
+
+	// Free form description, used when the error doesn't come from a response.
+	What string
+}
+
+func (e APIError) Error() string {
+	if e.What != "" {
+		return e.What
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("%v: status code %v: %v (code %v)", e.Endpoint, e.StatusCode, e.Message, e.Code)
+	}
+	return fmt.Sprintf("%v: status code %v", e.Endpoint, e.StatusCode)
+}
+
+// Is reports whether the error matches one of the sentinel errors, like ErrFileNotFound.
+func (e APIError) Is(target error) bool {
+	if err, ok := errorCodes[e.Code]; ok && err == target {
+		return true
+	}
+	switch target {
+	case ErrInvalidKey:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrExportThrottled:
+		return e.Endpoint == "export" && e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// errorPayload is the error format of both json and xml responses.
+type errorPayload struct {
+	Success *bool `json:"success"`
+	Error   struct {
+		Code    int    `json:"code" xml:"code"`
+		Message string `json:"message" xml:"message"`
+	} `json:"error"`
+}
+
+// checkResponse returns an APIError if the call failed,
+// either with a non 200 status or with an error payload.
+func checkResponse(endpoint string, statusCode int, body []byte) error {
+
+	var payload errorPayload
+	trimmed := bytes.TrimSpace(body)
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		json.Unmarshal(trimmed, &payload)
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		xml.Unmarshal(trimmed, &payload.Error)
+	}
+
+	failed := payload.Success != nil && !*payload.Success && payload.Error.Code != 0
+	if statusCode == http.StatusOK && !failed {
+		return nil
+	}
+
+	return APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint,
+		Code:       payload.Error.Code,
+		Message:    payload.Error.Message,
+	}
+}
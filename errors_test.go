@@ -0,0 +1,63 @@
+package crowdin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       error
+		code       int
+		message    string
+	}{
+		{"success", 200, `{"success":true}`, nil, 0, ""},
+		{"json error", 400, `{"success":false,"error":{"code":8,"message":"File was not found"}}`, ErrFileNotFound, 8, "File was not found"},
+		{"xml error", 400, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<error><code>50</code><message>Directory with such name already exists</message></error>", ErrDirectoryExists, 50, "Directory with such name already exists"},
+		{"error with status ok", 200, `{"success":false,"error":{"code":3,"message":"API key is not valid"}}`, ErrInvalidKey, 3, "API key is not valid"},
+		{"unauthorized", 401, `Unauthorized`, ErrInvalidKey, 0, ""},
+	}
+
+	for _, test := range tests {
+		err := checkResponse("add-directory", test.statusCode, []byte(test.body))
+		if test.want == nil {
+			if err != nil {
+				t.Errorf("%v: expected no error, got %v", test.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.want) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.want, err)
+		}
+
+		var apiErr APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%v: expected APIError, got %T", test.name, err)
+		}
+		if apiErr.StatusCode != test.statusCode || apiErr.Code != test.code || apiErr.Message != test.message || apiErr.Endpoint != "add-directory" {
+			t.Errorf("%v: unexpected %#v", test.name, apiErr)
+		}
+	}
+}
+
+func TestCrowdin_AddDirectory_exists(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/add-directory", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false,"error":{"code":50,"message":"Directory with such name already exists"}}`))
+	})
+
+	_, err := crowdin.AddDirectory("strings")
+	if !errors.Is(err, ErrDirectoryExists) {
+		t.Errorf("Expected %v, got %v", ErrDirectoryExists, err)
+	}
+	if errors.Is(err, ErrFileExists) {
+		t.Errorf("Expected %v not to match %v", err, ErrFileExists)
+	}
+}
@@ -0,0 +1,122 @@
+package crowdin
+
+import (
+	"context"
+	"time"
+)
+
+// BuildStatus is the result of ExportTranslations.
+type BuildStatus string
+
+// Results of ExportTranslations.
+const (
+	// ExportBuilt - Export was started, as the project changed since the last build.
+	ExportBuilt BuildStatus = "built"
+	// ExportSkipped - Export was skipped, as nothing changed since the last build.
+	ExportSkipped BuildStatus = "skipped"
+	// ExportThrottled - Export wasn't requested, as the project was exported less than ExportInterval ago, see SetExportStore.
+	ExportThrottled BuildStatus = "throttled"
+)
+
+// Statuses of GetExportStatus.
+const (
+	// ExportInProgress - Export is running.
+	ExportInProgress = "in-progress"
+	// ExportFinished - Export is done and translations can be downloaded.
+	ExportFinished = "finished"
+)
+
+// Default intervals between polls of ExportAndWait.
+const (
+	DefaultExportMinInterval = time.Second
+	DefaultExportMaxInterval = 10 * time.Second
+)
+
+// ExportWaitOptions are options for ExportAndWait call
+type ExportWaitOptions struct {
+	// Called with every polled status, including the final one. May be nil.
+	Progress func(status *ExportStatus)
+
+	// Delay before the first poll, doubled after every poll. DefaultExportMinInterval if zero.
+	MinInterval time.Duration
+
+	// Max delay between polls. DefaultExportMaxInterval if zero.
+	MaxInterval time.Duration
+}
+
+// ExportResult is a result of ExportAndWait
+type ExportResult struct {
+	// ExportBuilt, ExportSkipped or ExportThrottled.
+	Status BuildStatus
+
+	// Time when the project can be exported again, if the export was throttled.
+	ThrottledUntil time.Time
+
+	// The last polled status, nil if the export was skipped.
+	Export *ExportStatus
+}
+
+// ExportAndWait - Export translations and wait until the build is finished.
+// Skipped export, when nothing changed since the last build, and throttled export are a success,
+// as the translations of the last build can be downloaded.
+func (crowdin *Crowdin) ExportAndWait(options *ExportWaitOptions) (*ExportResult, error) {
+	return crowdin.ExportAndWaitContext(context.Background(), options)
+}
+
+// ExportAndWaitContext - Same as ExportAndWait, with a context. Polling stops when ctx is done.
+func (crowdin *Crowdin) ExportAndWaitContext(ctx context.Context, options *ExportWaitOptions) (*ExportResult, error) {
+
+	if options == nil {
+		options = &ExportWaitOptions{}
+	}
+
+	interval := options.MinInterval
+	if interval <= 0 {
+		interval = DefaultExportMinInterval
+	}
+
+	maxInterval := options.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultExportMaxInterval
+	}
+
+	export, err := crowdin.ExportTranslationsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExportResult{Status: export.Success.Status, ThrottledUntil: export.ThrottledUntil}
+	if result.Status != ExportBuilt {
+		return result, nil
+	}
+
+	for {
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+
+		status, err := crowdin.GetExportStatusContext(ctx)
+		if err != nil {
+			return result, err
+		}
+
+		result.Export = status
+		if options.Progress != nil {
+			options.Progress(status)
+		}
+
+		if status.Status == ExportFinished {
+			return result, nil
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package crowdin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCrowdin_ExportAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":{"status":"built"}}`))
+	})
+
+	polls := 0
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			fmt.Fprintf(w, `{"status":"in-progress","progress":"%v","current_file":"menu.csv","current_language":"ru"}`, polls*40)
+			return
+		}
+		w.Write([]byte(`{"status":"finished","progress":100,"last_build":"2017-06-06T12:44:56+0000"}`))
+	})
+
+	var progress []Int
+	result, err := crowdin.ExportAndWait(&ExportWaitOptions{
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+		Progress: func(status *ExportStatus) {
+			progress = append(progress, status.Progress)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Status != ExportBuilt || result.Export.Status != ExportFinished {
+		t.Errorf("Unexpected %+v", result)
+	}
+	if fmt.Sprint(progress) != "[40 80 100]" {
+		t.Errorf("Expected %v, got %v", "[40 80 100]", progress)
+	}
+	if result.Export.LastBuild.Year() != 2017 {
+		t.Errorf("Expected %v, got %v", 2017, result.Export.LastBuild)
+	}
+}
+
+func TestCrowdin_ExportAndWait_skipped(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":{"status":"skipped"}}`))
+	})
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no polls")
+	})
+
+	result, err := crowdin.ExportAndWait(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Status != ExportSkipped {
+		t.Errorf("Expected %v, got %v", ExportSkipped, result.Status)
+	}
+}
+
+func TestCrowdin_ExportAndWait_deadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/export", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":{"status":"built"}}`))
+	})
+	mux.HandleFunc("/project-name/export-status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"in-progress","progress":"10"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := crowdin.ExportAndWaitContext(ctx, &ExportWaitOptions{MinInterval: 5 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,316 @@
+package crowdin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsafePath is returned by DownloadAndExtract for archives with entries that would be written
+// outside of LocalPath, e.g. with ".." or absolute names. Nothing is extracted from such archives.
+var ErrUnsafePath = errors.New("crowdin: unsafe path in archive")
+
+// ExtractOptions are options for DownloadAndExtract call
+type ExtractOptions struct {
+	// Language code or "all" to download translations to all languages.
+	Package string
+
+	// Local directory the translations are extracted to.
+	LocalPath string
+
+	// Path of every translation relative to LocalPath, with the placeholders of the language and the source file,
+	// e.g. "res/values-%android_code%/%file_name%.xml". Empty pattern keeps the layout of the package,
+	// where translations are in directories named by Crowdin language codes.
+	Pattern string
+
+	// Values of placeholders by language, that override the computed ones,
+	// e.g. {"android_code": {"zh-CN": "zh-rCN"}}.
+	LanguagesMapping map[string]map[string]string
+}
+
+// ExtractedFile is a translation written by DownloadAndExtract.
+type ExtractedFile struct {
+	// Crowdin code of the language.
+	Language string
+
+	// Path of the source file in the project, e.g. "ui/menu.csv".
+	Name string
+
+	// Path of the written file.
+	LocalPath string
+
+	// Whether the file was created or its content was different. Unchanged files aren't rewritten.
+	Changed bool
+}
+
+// ExtractResult is a result of DownloadAndExtract
+type ExtractResult struct {
+	Files []ExtractedFile
+
+	// Whether the download was skipped, as nothing was built since the last one, see SetCache.
+	Cached bool
+}
+
+// Changed returns the files whose content was changed or created by the extraction.
+func (result *ExtractResult) Changed() []ExtractedFile {
+	var changed []ExtractedFile
+	for _, file := range result.Files {
+		if file.Changed {
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+// DownloadAndExtract - Download the package of translations and extract it to the local directory,
+// with the paths of the files built from Pattern.
+func (crowdin *Crowdin) DownloadAndExtract(options *ExtractOptions) (*ExtractResult, error) {
+	return crowdin.DownloadAndExtractContext(context.Background(), options)
+}
+
+// DownloadAndExtractContext - Same as DownloadAndExtract, with a context.
+func (crowdin *Crowdin) DownloadAndExtractContext(ctx context.Context, options *ExtractOptions) (*ExtractResult, error) {
+
+	if options == nil || options.Package == "" {
+		return nil, invalid("Package can't be empty")
+	}
+
+	if options.LocalPath == "" {
+		return nil, invalid("LocalPath can't be empty")
+	}
+
+	if err := validatePattern(options.Pattern); err != nil {
+		return nil, err
+	}
+
+	var result *ExtractResult
+	cached, err := crowdin.cachedDownload(ctx, "extract/"+options.Package+" "+options.LocalPath+" "+settingsKey(struct {
+		Pattern          string
+		LanguagesMapping map[string]map[string]string
+	}{options.Pattern, options.LanguagesMapping}), func() ([]string, error) {
+		var err error
+		result, err = crowdin.extract(ctx, options.Package, options.LocalPath, func(language, name string) (string, bool) {
+			if options.Pattern == "" {
+				return path.Join(language, name), true
+			}
+			return expandPattern(options.Pattern, language, name, options.LanguagesMapping), true
+		})
+		if err != nil {
+			return nil, err
+		}
+		var files []string
+		for _, file := range result.Files {
+			files = append(files, file.LocalPath)
+		}
+		return files, nil
+	})
+
+	if cached {
+		return &ExtractResult{Cached: true}, nil
+	}
+	return result, err
+}
+
+// extract downloads the package and extracts it to the directory. Target returns the path of the
+// translation of the file relative to the directory, or false to skip it.
+func (crowdin *Crowdin) extract(ctx context.Context, pkg, dir string, target func(language, name string) (string, bool)) (*ExtractResult, error) {
+
+	archive, err := os.CreateTemp("", "crowdin-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	err = crowdin.DownloadTranslationsToContext(ctx, archive, &DownloadOptions{Package: pkg})
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		crowdin.log(err)
+		return nil, err
+	}
+
+	// every entry is checked and written to a temporary file first, so nothing is
+	// replaced and no directory is created unless the whole archive is valid
+	staging, err := os.MkdirTemp(existingDir(dir), ".crowdin-extract-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	var staged []stagedFile
+
+	targets := make(map[string]string)
+
+	for _, entry := range reader.File {
+
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+
+		name, err := entryName(entry)
+		if err != nil {
+			crowdin.log(err)
+			return nil, err
+		}
+
+		language, name, ok := strings.Cut(name, "/")
+		if !ok {
+			continue
+		}
+
+		translation, ok := target(language, name)
+		if !ok {
+			continue
+		}
+
+		if other, ok := targets[translation]; ok {
+			return nil, fmt.Errorf("%w: %v and %v are both extracted to %v", ErrUnsafePath, other, entry.Name, translation)
+		}
+		targets[translation] = entry.Name
+
+		local := filepath.Join(dir, filepath.FromSlash(translation))
+		if !insideDir(dir, local) {
+			return nil, fmt.Errorf("%w: %v is extracted to %v", ErrUnsafePath, entry.Name, local)
+		}
+
+		file := stagedFile{ExtractedFile: ExtractedFile{Language: language, Name: name, LocalPath: local}}
+		file.temp, file.Changed, err = stageFile(ctx, entry, staging, local)
+		staged = append(staged, file)
+		if err != nil {
+			crowdin.log(err)
+			return nil, err
+		}
+	}
+
+	result := &ExtractResult{}
+
+	for i := range staged {
+
+		file := &staged[i]
+		if file.Changed {
+			if err := os.MkdirAll(filepath.Dir(file.LocalPath), 0o755); err != nil {
+				crowdin.log(err)
+				return result, err
+			}
+			if err := os.Rename(file.temp, file.LocalPath); err != nil {
+				crowdin.log(err)
+				return result, err
+			}
+		}
+
+		result.Files = append(result.Files, file.ExtractedFile)
+	}
+
+	return result, nil
+}
+
+// stagedFile is an extracted file, written to a temporary file until the whole archive is checked.
+type stagedFile struct {
+	ExtractedFile
+	temp string
+}
+
+// entryName returns the name of the entry, or ErrUnsafePath if it's absolute,
+// goes up the tree or isn't a regular file.
+func entryName(entry *zip.File) (string, error) {
+
+	name := strings.ReplaceAll(entry.Name, "\\", "/")
+
+	if !entry.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: %v is not a regular file", ErrUnsafePath, entry.Name)
+	}
+
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(entry.Name) || filepath.VolumeName(entry.Name) != "" {
+		return "", fmt.Errorf("%w: %v is absolute", ErrUnsafePath, entry.Name)
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("%w: %v is outside of the archive", ErrUnsafePath, entry.Name)
+		}
+	}
+
+	return path.Clean(name), nil
+}
+
+// insideDir reports whether the path is inside the directory.
+func insideDir(dir, name string) bool {
+	rel, err := filepath.Rel(dir, name)
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// existingDir returns the directory itself, or its closest ancestor that exists.
+func existingDir(dir string) string {
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// stageFile writes the content of the zip entry to a temporary file in the staging directory.
+// The checksum of the entry is verified while it's read. Changed reports whether the content
+// differs from the current content of the local file.
+func stageFile(ctx context.Context, entry *zip.File, staging, local string) (temp string, changed bool, err error) {
+
+	r, err := entry.Open()
+	if err != nil {
+		return "", false, err
+	}
+	defer r.Close()
+
+	hash := sha256.New()
+	temp, err = writeTemp(filepath.Join(staging, filepath.Base(local)), func(w io.Writer) error {
+		_, err := io.Copy(io.MultiWriter(w, hash), &contextReader{ctx: ctx, r: r})
+		return err
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("%v: %w", entry.Name, err)
+	}
+
+	current, err := fileHash(local)
+	if err != nil {
+		return temp, true, nil
+	}
+
+	return temp, !bytes.Equal(current, hash.Sum(nil)), nil
+}
+
+// fileHash returns sha256 of the content of the file.
+func fileHash(name string) ([]byte, error) {
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,160 @@
+package crowdin
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type zipEntry struct {
+	name    string
+	content string
+	crc     uint32
+}
+
+func serveZip(t *testing.T, entries ...zipEntry) {
+	mux.HandleFunc("/project-name/download/all.zip", func(w http.ResponseWriter, r *http.Request) {
+		var buffer bytes.Buffer
+		zw := zip.NewWriter(&buffer)
+		for _, entry := range entries {
+			if entry.crc != 0 {
+				fw, err := zw.CreateRaw(&zip.FileHeader{
+					Name:               entry.name,
+					Method:             zip.Store,
+					CRC32:              entry.crc,
+					CompressedSize64:   uint64(len(entry.content)),
+					UncompressedSize64: uint64(len(entry.content)),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				fw.Write([]byte(entry.content))
+				continue
+			}
+			fw, err := zw.Create(entry.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fw.Write([]byte(entry.content))
+		}
+		zw.Close()
+		w.Write(buffer.Bytes())
+	})
+}
+
+func TestCrowdin_DownloadAndExtract_changed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveZip(t,
+		zipEntry{name: "ru/ui/menu.csv", content: "play,Играть\n"},
+		zipEntry{name: "de/ui/menu.csv", content: "play,Spielen\n"},
+	)
+
+	dir := t.TempDir()
+	options := &ExtractOptions{Package: "all", LocalPath: dir}
+
+	result, err := crowdin.DownloadAndExtract(options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Changed()) != 2 {
+		t.Errorf("Expected %v, got %v", 2, len(result.Changed()))
+	}
+
+	os.WriteFile(filepath.Join(dir, "de", "ui", "menu.csv"), []byte("edited"), 0o644)
+
+	result, err = crowdin.DownloadAndExtract(options)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	changed := result.Changed()
+	if len(result.Files) != 2 || len(changed) != 1 || changed[0].Language != "de" || changed[0].Name != "ui/menu.csv" {
+		t.Errorf("Unexpected changed files %+v", changed)
+	}
+
+	if content, _ := os.ReadFile(filepath.Join(dir, "de", "ui", "menu.csv")); string(content) != "play,Spielen\n" {
+		t.Errorf("Expected %q, got %q", "play,Spielen\n", content)
+	}
+
+	temps, _ := filepath.Glob(filepath.Join(dir, "*", "ui", ".*.tmp"))
+	if len(temps) != 0 {
+		t.Errorf("Expected no temporary files, got %v", temps)
+	}
+}
+
+func TestCrowdin_DownloadAndExtract_unsafe(t *testing.T) {
+	tests := map[string]zipEntry{
+		"parent":   {name: "ru/../../evil.csv", content: "evil"},
+		"absolute": {name: "/etc/evil.csv", content: "evil"},
+	}
+
+	for name, entry := range tests {
+		setup()
+		serveZip(t, zipEntry{name: "ru/menu.csv", content: "play,Играть\n"}, entry)
+
+		parent := t.TempDir()
+		dir := filepath.Join(parent, "out")
+		_, err := crowdin.DownloadAndExtract(&ExtractOptions{Package: "all", LocalPath: dir})
+		if !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("%v: expected %v, got %v", name, ErrUnsafePath, err)
+		}
+		if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+			t.Errorf("%v: expected nothing extracted, got %v", name, entries)
+		}
+		teardown()
+	}
+}
+
+func TestCrowdin_DownloadAndExtract_checksum(t *testing.T) {
+	setup()
+	defer teardown()
+
+	serveZip(t,
+		zipEntry{name: "de/menu.csv", content: "play,Spielen\n"},
+		zipEntry{name: "ru/menu.csv", content: "play,Играть\n"},
+		zipEntry{name: "ru/store.csv", content: "buy,Купить\n", crc: 1},
+	)
+
+	dir := t.TempDir()
+	local := filepath.Join(dir, "ru", "menu.csv")
+	os.MkdirAll(filepath.Dir(local), 0o755)
+	os.WriteFile(local, []byte("previous"), 0o644)
+
+	_, err := crowdin.DownloadAndExtract(&ExtractOptions{Package: "all", LocalPath: dir})
+	if !errors.Is(err, zip.ErrChecksum) {
+		t.Errorf("Expected %v, got %v", zip.ErrChecksum, err)
+	}
+	if content, _ := os.ReadFile(local); string(content) != "previous" {
+		t.Errorf("Expected %q, got %q", "previous", content)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(local)); len(entries) != 1 {
+		t.Errorf("Expected only %v, got %v", local, entries)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected only %v, got %v", filepath.Dir(local), entries)
+	}
+}
+
+func TestCrowdin_DownloadTranslations_keepsFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/download/all.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	local := filepath.Join(t.TempDir(), "all.zip")
+	os.WriteFile(local, []byte("previous"), 0o644)
+
+	if err := crowdin.DownloadTranslations(&DownloadOptions{Package: "all", LocalPath: local}); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if content, _ := os.ReadFile(local); string(content) != "previous" {
+		t.Errorf("Expected %q, got %q", "previous", content)
+	}
+}
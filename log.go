@@ -0,0 +1,156 @@
+package crowdin
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// redacted replaces the values of secrets in logs and errors.
+const redacted = "REDACTED"
+
+// secretParams are the query, form and payload fields that hold credentials.
+var secretParams = map[string]bool{
+	"key":         true,
+	"account-key": true,
+	"api_key":     true,
+	"token":       true,
+}
+
+// secretFields matches secrets in json and xml response bodies, e.g. the key of a created project.
+var secretFields = regexp.MustCompile(`("(?:key|account-key|api_key|token)"\s*:\s*")[^"]*(")|(<(?:key|account-key|api_key|token)>)[^<]*(</)`)
+
+// SetLogger sets the logger of structured events. Every request attempt is logged
+// with its endpoint, status, duration, size of the response and attempt number.
+// Credentials are never logged. Nil disables the logging.
+func (crowdin *Crowdin) SetLogger(logger *slog.Logger) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
+	crowdin.logger = logger
+}
+
+// logResponse logs the result of the request attempt. Successful responses
+// are logged when their body is closed, so the duration and size cover the whole download.
+func (crowdin *Crowdin) logResponse(req *Request, response *http.Response, err error, start time.Time) *http.Response {
+
+	crowdin.mu.RLock()
+	logger := crowdin.logger
+	crowdin.mu.RUnlock()
+
+	if logger == nil {
+		return response
+	}
+
+	ctx := req.HTTP.Context()
+	attrs := []slog.Attr{
+		slog.String("endpoint", req.Endpoint),
+		slog.String("method", req.HTTP.Method),
+		slog.String("url", redactURL(req.HTTP.URL)),
+		slog.Int("attempt", req.Attempt),
+		slog.Any("params", redactParams(req.Params)),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)), slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelError, "crowdin request failed", attrs...)
+		return response
+	}
+
+	level := slog.LevelInfo
+	if response.StatusCode != http.StatusOK {
+		level = slog.LevelWarn
+	}
+
+	response.Body = &loggedBody{
+		ReadCloser: response.Body,
+		done: func(bytes int64) {
+			attrs = append(attrs,
+				slog.Int("status", response.StatusCode),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int64("bytes", bytes),
+			)
+			logger.LogAttrs(ctx, level, "crowdin request", attrs...)
+		},
+	}
+
+	return response
+}
+
+// loggedBody counts the bytes read from the body and reports them on close.
+type loggedBody struct {
+	io.ReadCloser
+	bytes int64
+	once  sync.Once
+	done  func(bytes int64)
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes += int64(n)
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	b.once.Do(func() {
+		b.done(b.bytes)
+	})
+	return b.ReadCloser.Close()
+}
+
+// redactURL returns the url with credentials in query replaced.
+func redactURL(u *url.URL) string {
+
+	query := u.Query()
+	changed := false
+	for k := range query {
+		if secretParams[k] {
+			query.Set(k, redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return u.String()
+	}
+
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return clone.String()
+}
+
+func redactParams(params map[string]string) map[string]string {
+
+	result := make(map[string]string, len(params))
+	for k, v := range params {
+		if secretParams[k] {
+			v = redacted
+		}
+		result[k] = v
+	}
+
+	return result
+}
+
+// redactError removes credentials from the url of transport errors,
+// as they are returned to the caller and usually end up in logs.
+func redactError(err error) error {
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if u, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+			urlErr.URL = redactURL(u)
+		}
+	}
+
+	return err
+}
+
+// redactBody hides secrets in the response body before it's logged.
+func redactBody(body string) string {
+	return secretFields.ReplaceAllString(body, "${1}${3}"+redacted+"${2}${4}")
+}
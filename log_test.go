@@ -0,0 +1,78 @@
+package crowdin
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCrowdin_SetLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetProject("secret-key", "project-name")
+
+	mux.HandleFunc("/project-name/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"details":{"name":"Game"}}`))
+	})
+
+	var buffer bytes.Buffer
+	crowdin.SetLogger(slog.New(slog.NewJSONHandler(&buffer, nil)))
+
+	if _, err := crowdin.GetProjectDetails(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Contains(buffer.String(), "secret-key") {
+		t.Errorf("Expected key to be redacted, got %v", buffer.String())
+	}
+
+	var event struct {
+		Endpoint string `json:"endpoint"`
+		Status   int    `json:"status"`
+		Attempt  int    `json:"attempt"`
+		Bytes    int    `json:"bytes"`
+		URL      string `json:"url"`
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &event); err != nil {
+		t.Fatalf("Expected json event, got %v", buffer.String())
+	}
+	if event.Endpoint != "info" || event.Status != 200 || event.Attempt != 1 || event.Bytes != 27 {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if !strings.Contains(event.URL, "key=REDACTED") {
+		t.Errorf("Expected redacted url, got %v", event.URL)
+	}
+}
+
+func TestCrowdin_transportErrorRedacted(t *testing.T) {
+	setup()
+	teardown()
+
+	crowdin.SetProject("secret-key", "project-name")
+
+	_, err := crowdin.GetProjectDetails()
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "secret-key") {
+		t.Errorf("Expected key to be redacted, got %v", err)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"project":{"success":true,"key":"secret-key","url":"https://crowdin.com/project/game"}}`
+	want := `{"project":{"success":true,"key":"REDACTED","url":"https://crowdin.com/project/game"}}`
+	if got := redactBody(body); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	body = `<project><key>secret-key</key></project>`
+	want = `<project><key>REDACTED</key></project>`
+	if got := redactBody(body); got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,82 @@
+package crowdin
+
+import (
+	"net/http"
+	"sort"
+)
+
+// Request is a single attempt of an API call, as seen by middlewares.
+type Request struct {
+	// Endpoint of the call, e.g. "add-file" or "status".
+	Endpoint string
+
+	// Params of the call. They are already encoded into HTTP request, so changing them has no effect.
+	Params      map[string]string
+	ParamsArray map[string][]string
+
+	// Form keys of the uploaded files, e.g. "files[strings.csv]".
+	Files []string
+
+	// Number of the attempt, starting from 1. It's bigger than 1 when the call is retried.
+	Attempt int
+
+	// HTTP request that is going to be sent. Middlewares can set headers on it or replace it.
+	HTTP *http.Request
+
+	// oneShot is set when one of the files can't be sent twice, so the call isn't retried.
+	oneShot bool
+}
+
+// Handler sends the request and returns the HTTP response.
+type Handler func(req *Request) (*http.Response, error)
+
+// Middleware wraps the handler of every API call.
+// It may inspect or change the request before calling next, and inspect the response after.
+// A middleware that reads the response body must replace it, so the client can read it too.
+type Middleware func(next Handler) Handler
+
+// Use adds middlewares to the client. The first added middleware is the outermost one.
+// Middlewares are called for every attempt of a retried call.
+func (crowdin *Crowdin) Use(middlewares ...Middleware) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
+	crowdin.middlewares = append(crowdin.middlewares, middlewares...)
+}
+
+// handler returns the chain of middlewares ending with the http client.
+func (crowdin *Crowdin) handler() Handler {
+
+	crowdin.mu.RLock()
+	defer crowdin.mu.RUnlock()
+
+	client := crowdin.config.client
+	handler := Handler(func(req *Request) (*http.Response, error) {
+		return client.Do(req.HTTP)
+	})
+
+	for i := len(crowdin.middlewares) - 1; i >= 0; i-- {
+		handler = crowdin.middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// newRequest returns the description of the call with given params, for middlewares.
+func newRequest(endpoint string, params map[string]string, paramsArray map[string][]string, files map[string]FileSource) *Request {
+
+	req := &Request{
+		Endpoint:    endpoint,
+		Params:      params,
+		ParamsArray: paramsArray,
+	}
+
+	for key, source := range files {
+		req.Files = append(req.Files, key)
+		if source, ok := source.(oneShotSource); ok && source.oneShot() {
+			req.oneShot = true
+		}
+	}
+	sort.Strings(req.Files)
+
+	return req
+}
@@ -0,0 +1,95 @@
+package crowdin
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrowdin_Use(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/language-status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "42" {
+			t.Errorf("Expected %v, got %v", "42", r.Header.Get("X-Request-Id"))
+		}
+		w.Write([]byte(`{"files":[]}`))
+	})
+
+	var calls []string
+	var endpoint, language string
+	var status int
+
+	crowdin.Use(
+		func(next Handler) Handler {
+			return func(req *Request) (*http.Response, error) {
+				calls = append(calls, "outer")
+				endpoint = req.Endpoint
+				language = req.Params["language"]
+				response, err := next(req)
+				if response != nil {
+					status = response.StatusCode
+				}
+				return response, err
+			}
+		},
+		func(next Handler) Handler {
+			return func(req *Request) (*http.Response, error) {
+				calls = append(calls, "inner")
+				req.HTTP.Header.Set("X-Request-Id", "42")
+				return next(req)
+			}
+		},
+	)
+
+	if _, err := crowdin.GetLanguageStatus("ru"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(calls, []string{"outer", "inner"}) {
+		t.Errorf("Expected %v, got %v", []string{"outer", "inner"}, calls)
+	}
+	if endpoint != "language-status" || language != "ru" || status != http.StatusOK {
+		t.Errorf("Unexpected endpoint %v, language %v, status %v", endpoint, language, status)
+	}
+}
+
+func TestCrowdin_Use_answerWithoutNext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.Use(func(next Handler) Handler {
+		return func(req *Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{"success":true}`)),
+			}, nil
+		}
+	})
+
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 10; i++ {
+		_, err := crowdin.UpdateFile(&UpdateFileOptions{
+			Sources: map[string]FileSource{"strings.csv": FromReader("strings.csv", bytes.NewReader([]byte("play,Play\n")))},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// the goroutines streaming the bodies that were never sent must exit
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected %v goroutines, got %v", before, after)
+	}
+}
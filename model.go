@@ -1,17 +1,12 @@
 package crowdin
 
+import "time"
+
 // AddFileOptions used for AddFile() API call
 type AddFileOptions struct {
 	// Note: Used only when uploading CSV (or XLS/XLSX) file to define data columns mapping.
-	// Acceptable value is the combination of the following constants:
-	// "identifier" — Column contains string identifier.
-	// "source_phrase" — Column contains only source string (in result string will contain same string).
-	// "source_or_translation" — Column contains source string but when exporting same column should contain translation (also when uploading existing translations, the value from this column will be used as a translated string).
-	// "translation" — Column contains translated string (when imported file already contains translations).
-	// "context" — Column contains some comments on source string. Context information.
-	// "max_length" — Column contains max. length of translation for this string.
-	// "none" — Do not import column.
-	Scheme string
+	// Acceptable value is the combination of SchemeColumn constants, see NewScheme.
+	Scheme Scheme
 
 	// Used when uploading CSV (or XLS/XLSX) files via API. Defines whether first line should be imported or it contains columns headers. May not contain value.
 	FirstLineContainsHeader bool
@@ -19,64 +14,28 @@ type AddFileOptions struct {
 	// Files array that should be added to Crowdin project. Array keys should contain file names with path in Crowdin project.
 	Files map[string]string
 
-	// Acceptable values are:
-	// empty value or "auto" — Try to detect file type by extension or MIME type
-	// "gettext" — GNU GetText (*.po, *.pot)
-	// "qtts" — Nokia Qt (*.ts)
-	// "dklang" — Delphi DKLang (*.dklang)
-	// "android" — Android (*.xml)
-	// "resx" — .NET (*.resx)
-	// "properties" — Java (*.properties)
-	// "macosx" — Mac OS X / iOS (*.strings)
-	// "blackberry" — BlackBerry (*.rrc)
-	// "Symbian" — Symbian (*.lXX)
-	// "flex" — Adobe Flex (*.properties)
-	// "bada" — Samsung Bada (*.xml)
-	// "txt" — Plain Text (*.txt)
-	// "srt" — SubRip .srt (*.srt)
-	// "sbv" — Youtube .sbv (*.sbv)
-	// "xliff" — XLIFF (*.xliff)
-	// "html" — HTML (*.html, *.htm, *.xhtml, *.xhtm)
-	// "dtd" — Mozilla DTD (*.dtd)
-	// "chrome" — Google Chrome Extension (*.json)
-	// "yaml" — Ruby On Rails (*.yaml)
-	// "csv" — Comma Separated Values (*.csv)
-	// "rc" — Windows Resources (*.rc)
-	// "wxl" — WiX Installer Resources (*.wxl)
-	// "nsh" — NSIS Installer Resources (*.nsh)
-	// "joomla" — Joomla localizable resources (*.ini)
-	// "ini" — Generic INI (*.ini)
-	// "isl" — ISL (*.isl)
-	// "resw" — Windows 8 Metro (*.resw)
-	// "resjson" — Windows 8 Metro (*.resjson)
-	// "docx" — Microsoft Office and OpenOffice.org Documents (*.docx, *.dotx, *.odt, *.ott, *.xslx, *.xltx, *.pptx, *.potx, *.ods, *.ots, *.odg, *.otg, *.odp, *.otp, *.idml)
-	// "md" — Markdown (*.md, *.text, *.markdown...)
-	// "mediawiki" — MediaWiki (*.wiki, *.wikitext, *.mediawiki)
-	// "play" — Play Framework
-	// "haml" — Haml (*.haml)
-	// "arb" — Application Resource Bundle (*.arb)
-	// "vtt" — Video Subtitling and WebVTT (*.vtt)
-	Type string
+	// Same as Files, but the content is read from any source, e.g. FromReader or FromFS.
+	Sources map[string]FileSource
+
+	// Empty value or FileTypeAuto — Try to detect file type by extension or MIME type.
+	// Acceptable values are the FileType constants.
+	Type FileType
 }
 
 // UpdateFileOptions used for UpdateFile() API call
 type UpdateFileOptions struct {
 	// Note: Used only when uploading CSV (or XLS/XLSX) file to define data columns mapping.
-	// Acceptable value is the combination of the following constants:
-	// "identifier" — Column contains string identifier.
-	// "source_phrase" — Column contains only source string (in result string will contain same string).
-	// "source_or_translation" — Column contains source string but when exporting same column should contain translation (also when uploading existing translations, the value from this column will be used as a translated string).
-	// "translation" — Column contains translated string (when imported file already contains translations).
-	// "context" — Column contains some comments on source string. Context information.
-	// "max_length" — Column contains max. length of translation for this string.
-	// "none" — Do not import column.
-	Scheme string
+	// Acceptable value is the combination of SchemeColumn constants, see NewScheme.
+	Scheme Scheme
 
 	// Used when uploading CSV (or XLS/XLSX) files via API. Defines whether first line should be imported or it contains columns headers. May not contain value.
 	FirstLineContainsHeader bool
 
 	// Files array that should be added to Crowdin project. Array keys should contain file names with path in Crowdin project.
 	Files map[string]string
+
+	// Same as Files, but the content is read from any source, e.g. FromReader or FromFS.
+	Sources map[string]FileSource
 }
 
 // UploadTranslationsOptions are options for UploadTranslations api call
@@ -87,6 +46,9 @@ type UploadTranslationsOptions struct {
 	// Translated files array. Array keys should contain file names in Crowdin.
 	Files map[string]string
 
+	// Same as Files, but the content is read from any source, e.g. FromReader or FromFS.
+	Sources map[string]FileSource
+
 	// Defines whether to add translation if there is the same translation previously added. Acceptable values are: 0 or 1. Default is 0.
 	ImportDuplicates string
 }
@@ -117,8 +79,8 @@ type CreateProjectOptions struct {
 	// An array of language codes project should be translate to.
 	Languages []string
 
-	// Project join policy. Acceptable values are: open, private
-	JoinPolicy string
+	// Project join policy. Acceptable values are: JoinPolicyOpen, JoinPolicyPrivate
+	JoinPolicy JoinPolicy
 }
 
 // EditProjectOptions are options for EditProject api call
@@ -129,8 +91,8 @@ type EditProjectOptions struct {
 	// An array of language codes project should be translate to.
 	Languages []string
 
-	// Project join policy. Acceptable values are: open, private
-	JoinPolicy string
+	// Project join policy. Acceptable values are: JoinPolicyOpen, JoinPolicyPrivate
+	JoinPolicy JoinPolicy
 }
 
 // ExportFileOptions are options for ExportFile api call
@@ -154,21 +116,13 @@ type DownloadOptions struct {
 	LocalPath string
 }
 
-type responseLanguageStatus struct {
-	Files []struct {
-		ID              string `json:"id"`
-		Name            string `json:"name"`
-		NodeType        string `json:"node_type"`
-		Phrases         string `json:"phrases"`
-		Translated      string `json:"translated"`
-		Approved        string `json:"approved"`
-		Words           string `json:"words"`
-		WordsTranslated string `json:"words_translated"`
-		WordsApproved   string `json:"words_approved"`
-	} `json:"files"`
+// LanguageStatus is a response struct of GetLanguageStatus
+type LanguageStatus struct {
+	Files FileTree `json:"files"`
 }
 
-type responseAddFile struct {
+// AddFileResult is a response struct of AddFile
+type AddFileResult struct {
 	Success bool `json:"success"`
 	Stats   struct {
 		Files []struct {
@@ -178,9 +132,24 @@ type responseAddFile struct {
 			Words   int    `json:"words"`
 		} `json:"files"`
 	} `json:"stats"`
+
+	// Files that failed, when the call was split into several requests.
+	Failures []FileFailure `json:"-"`
 }
 
-type responseUploadTranslation struct {
+// UpdateFileResult is a response struct of UpdateFile
+type UpdateFileResult struct {
+	Success bool `json:"success"`
+
+	// Status of every updated file, by file name.
+	Files map[string]string `json:"files"`
+
+	// Files that failed, when the call was split into several requests.
+	Failures []FileFailure `json:"-"`
+}
+
+// UploadTranslationResult is a response struct of UploadTranslations
+type UploadTranslationResult struct {
 	Success bool `json:"success"`
 	Stats   struct {
 		Files []struct {
@@ -188,9 +157,13 @@ type responseUploadTranslation struct {
 			Status string `json:"status"`
 		} `json:"files"`
 	} `json:"stats"`
+
+	// Files that failed, when the call was split into several requests.
+	Failures []FileFailure `json:"-"`
 }
 
-type responseManageProject struct {
+// ManageProjectResult is a response struct of CreateProject and EditProject
+type ManageProjectResult struct {
 	Project struct {
 		Success    bool   `json:"success"`
 		Invitation string `json:"invitation"`
@@ -199,37 +172,42 @@ type responseManageProject struct {
 	} `json:"project"`
 }
 
-type responseDeleteProject struct {
+// DeleteProjectResult is a response struct of DeleteProject
+type DeleteProjectResult struct {
 	Project struct {
 		Success bool `json:"success"`
 	} `json:"project"`
 }
 
-type responseExportTranslations struct {
+// ExportTranslationsResult is a response struct of ExportTranslations
+type ExportTranslationsResult struct {
 	Success struct {
-		Status string `json:"status"`
+		Status BuildStatus `json:"status"`
 	} `json:"success"`
+
+	// Time when the project can be exported again, if Status is ExportThrottled.
+	ThrottledUntil time.Time `json:"-"`
 }
 
 // TranslationStatus is a response struct
 type TranslationStatus struct {
 	Name               string `json:"name"`
 	Code               string `json:"code"`
-	Phrases            string `json:"phrases"`
-	Translated         string `json:"translated"`
-	Approved           string `json:"approved"`
-	Words              string `json:"words"`
-	WordsTranslated    string `json:"words_translated"`
-	WordsApproved      string `json:"words_approved"`
-	TranslatedProgress int    `json:"translated_progress"`
-	ApprovedProgress   int    `json:"approved_progress"`
+	Phrases            Int    `json:"phrases"`
+	Translated         Int    `json:"translated"`
+	Approved           Int    `json:"approved"`
+	Words              Int    `json:"words"`
+	WordsTranslated    Int    `json:"words_translated"`
+	WordsApproved      Int    `json:"words_approved"`
+	TranslatedProgress Int    `json:"translated_progress"`
+	ApprovedProgress   Int    `json:"approved_progress"`
 }
 
 // ExportStatus is a response struct
 type ExportStatus struct {
 	Status          string `json:"status"`
-	Progress        int    `json:"progress"`
-	LastBuild       string `json:"last_build"`
+	Progress        Int    `json:"progress"`
+	LastBuild       Time   `json:"last_build"`
 	Code            string `json:"code"`
 	Message         string `json:"message"`
 	CurrentFile     string `json:"current_file"`
@@ -238,19 +216,12 @@ type ExportStatus struct {
 
 // ProjectInfo is a response struct
 type ProjectInfo struct {
-	Files []struct {
-		Name         string `json:"name"`
-		NodeType     string `json:"node_type"`
-		Created      string `json:"created"`
-		LastUpdated  string `json:"last_updated"`
-		LastAccessed string `json:"last_accessed"`
-		LastRevision string `json:"last_revision"`
-	} `json:"files"`
+	Files    FileTree `json:"files"`
 	Language struct {
 		Name         string `json:"name"`
 		Code         string `json:"code"`
-		CanTranslate int    `json:"can_translate"`
-		CanApprove   int    `json:"can_approve"`
+		CanTranslate Int    `json:"can_translate"`
+		CanApprove   Int    `json:"can_approve"`
 	}
 	Details struct {
 		SourceLanguage struct {
@@ -259,16 +230,16 @@ type ProjectInfo struct {
 		} `json:"source_language"`
 		Name                  string `json:"name"`
 		Identifier            string `json:"identifier"`
-		Created               string `json:"created"`
+		Created               Time   `json:"created"`
 		Description           string `json:"description"`
 		JoinPolicy            string `json:"private"`
-		LastBuild             string `json:"last_build"`
-		LastActivity          string `json:"last_activity"`
-		ParticipantsCount     string `json:"participants_count"`
-		TotalStringsCount     string `json:"total_strings_count"`
-		TotalWordsCount       string `json:"total_words_count"`
-		DuplicateStringsCount int    `json:"duplicate_strings_count"`
-		DuplicateWordsCount   int    `json:"duplicate_words_count"`
+		LastBuild             Time   `json:"last_build"`
+		LastActivity          Time   `json:"last_activity"`
+		ParticipantsCount     Int    `json:"participants_count"`
+		TotalStringsCount     Int    `json:"total_strings_count"`
+		TotalWordsCount       Int    `json:"total_words_count"`
+		DuplicateStringsCount Int    `json:"duplicate_strings_count"`
+		DuplicateWordsCount   Int    `json:"duplicate_words_count"`
 		InviteURL             struct {
 			Translator  string `json:"translator"`
 			Proofreader string `json:"proofreader"`
@@ -288,6 +259,7 @@ type AccountDetails struct {
 	} `json:"projects"`
 }
 
-type responseGeneral struct {
+// GeneralResult is a response struct of calls that report only success
+type GeneralResult struct {
 	Success bool `json:"success"`
 }
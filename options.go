@@ -0,0 +1,200 @@
+package crowdin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default timeouts of the client created by New.
+const (
+	DefaultConnectTimeout  = 5 * time.Second
+	DefaultResponseTimeout = 40 * time.Second
+)
+
+// Default batching of AddFile, UpdateFile and UploadTranslations calls.
+const (
+	DefaultBatchSize        = 20
+	DefaultBatchParallelism = 4
+)
+
+// DefaultUserAgent is sent with every request, unless changed with WithUserAgent.
+const DefaultUserAgent = "go-crowdin"
+
+// Option configures the client created by NewWithOptions.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	connectTimeout    time.Duration
+	responseTimeout   time.Duration
+	timeout           time.Duration
+	apiBaseURL        string
+	apiAccountBaseURL string
+	userAgent         string
+	proxy             func(*http.Request) (*url.URL, error)
+	rootCAs           *x509.CertPool
+	client            *http.Client
+	batchSize         int
+	batchParallelism  int
+}
+
+// WithConnectTimeout - Max time to establish a connection with Crowdin.
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.connectTimeout = timeout
+	}
+}
+
+// WithResponseTimeout - Max time to wait for the response headers after the request was sent.
+func WithResponseTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.responseTimeout = timeout
+	}
+}
+
+// WithTimeout - Max time of a whole request, including upload and download of the body.
+// There is no limit by default, as uploads and downloads of big files can take long.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithBaseURL - Base URL of project API, "https://api.crowdin.com/api/project/" by default.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.apiBaseURL = withSlash(baseURL)
+	}
+}
+
+// WithAccountBaseURL - Base URL of account API, "https://api.crowdin.com/api/account/" by default.
+func WithAccountBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.apiAccountBaseURL = withSlash(baseURL)
+	}
+}
+
+// WithUserAgent - User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithProxy - Proxy of all the requests. Nil disables the proxy.
+// By default the proxy is taken from HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(o *clientOptions) {
+		if proxyURL == nil {
+			o.proxy = nil
+			return
+		}
+		o.proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithRootCAs - Certificate authorities used to verify Crowdin server, instead of the system ones.
+func WithRootCAs(rootCAs *x509.CertPool) Option {
+	return func(o *clientOptions) {
+		o.rootCAs = rootCAs
+	}
+}
+
+// WithHTTPClient - Custom http client. Timeouts, proxy and root CAs options are ignored when it's set.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.client = client
+	}
+}
+
+// WithBatchSize - Max number of files sent in one request. Calls with more files are split
+// into several requests, see BatchError. Zero or negative size disables the splitting.
+func WithBatchSize(size int) Option {
+	return func(o *clientOptions) {
+		o.batchSize = size
+	}
+}
+
+// WithBatchParallelism - Max number of requests of one split call that run at the same time.
+func WithBatchParallelism(parallelism int) Option {
+	return func(o *clientOptions) {
+		o.batchParallelism = parallelism
+	}
+}
+
+// NewWithOptions - create new instance of Crowdin API with given options.
+func NewWithOptions(token, project string, options ...Option) *Crowdin {
+
+	o := &clientOptions{
+		connectTimeout:    DefaultConnectTimeout,
+		responseTimeout:   DefaultResponseTimeout,
+		apiBaseURL:        apiBaseURL,
+		apiAccountBaseURL: apiAccountBaseURL,
+		userAgent:         DefaultUserAgent,
+		proxy:             http.ProxyFromEnvironment,
+		batchSize:         DefaultBatchSize,
+		batchParallelism:  DefaultBatchParallelism,
+	}
+
+	for _, option := range options {
+		option(o)
+	}
+
+	s := &Crowdin{}
+	s.config.apiBaseURL = o.apiBaseURL
+	s.config.apiAccountBaseURL = o.apiAccountBaseURL
+	s.config.token = token
+	s.config.project = project
+	s.config.userAgent = o.userAgent
+	s.config.client = o.client
+	s.batch.size = o.batchSize
+	s.batch.parallelism = o.batchParallelism
+
+	if s.config.client == nil {
+		s.config.client = &http.Client{
+			Transport: newTransport(o),
+			Timeout:   o.timeout,
+		}
+	}
+
+	return s
+}
+
+// newTransport returns a pooled transport, with the same defaults as http.DefaultTransport.
+func newTransport(o *clientOptions) *http.Transport {
+
+	transport := &http.Transport{
+		Proxy: o.proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   o.connectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: o.responseTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	if o.rootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    o.rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return transport
+}
+
+func withSlash(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/") {
+		return baseURL
+	}
+	return baseURL + "/"
+}
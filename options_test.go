@@ -0,0 +1,47 @@
+package crowdin
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/project/project-name/status" {
+			t.Errorf("Expected %v, got %v", "/api/project/project-name/status", r.URL.Path)
+		}
+		if r.UserAgent() != "game-sync/1.0" {
+			t.Errorf("Expected %v, got %v", "game-sync/1.0", r.UserAgent())
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	c := NewWithOptions("token", "project-name",
+		WithBaseURL(server.URL+"/api/project"),
+		WithUserAgent("game-sync/1.0"),
+		WithRootCAs(rootCAs),
+		WithProxy(nil),
+	)
+
+	if _, err := c.GetTranslationsStatus(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestNewWithOptions_httpClient(t *testing.T) {
+	client := &http.Client{}
+	c := NewWithOptions("token", "project-name", WithHTTPClient(client))
+
+	if c.config.client != client {
+		t.Errorf("Expected %v, got %v", client, c.config.client)
+	}
+	if c.config.apiBaseURL != apiBaseURL {
+		t.Errorf("Expected %v, got %v", apiBaseURL, c.config.apiBaseURL)
+	}
+}
@@ -0,0 +1,107 @@
+package crowdin
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Placeholders of translation patterns, e.g. "values-%android_code%/%original_file_name%".
+const (
+	PlaceholderTwoLettersCode       = "%two_letters_code%"
+	PlaceholderLocale               = "%locale%"
+	PlaceholderLocaleWithUnderscore = "%locale_with_underscore%"
+	PlaceholderAndroidCode          = "%android_code%"
+	PlaceholderOSXCode              = "%osx_code%"
+	PlaceholderOSXLocale            = "%osx_locale%"
+	PlaceholderOriginalFileName     = "%original_file_name%"
+	PlaceholderFileName             = "%file_name%"
+	PlaceholderFileExtension        = "%file_extension%"
+	PlaceholderOriginalPath         = "%original_path%"
+)
+
+var placeholderPattern = regexp.MustCompile(`%[a-z_]+%`)
+
+// localeRegions are the default regions of languages whose region code differs from the language code.
+var localeRegions = map[string]string{
+	"ar": "SA", "ca": "ES", "cs": "CZ", "da": "DK", "el": "GR", "en": "US", "et": "EE",
+	"fa": "IR", "ga": "IE", "he": "IL", "hi": "IN", "ja": "JP", "ko": "KR", "ms": "MY",
+	"nb": "NO", "sl": "SI", "sr": "SP", "sv": "SE", "uk": "UA", "vi": "VN", "zh": "CN",
+}
+
+// osxCodes are the codes of languages whose osx code isn't the locale.
+var osxCodes = map[string]string{
+	"zh-CN": "zh-Hans",
+	"zh-TW": "zh-Hant",
+}
+
+// validatePattern checks that all the placeholders of the pattern are known.
+func validatePattern(pattern string) error {
+
+	for _, placeholder := range placeholderPattern.FindAllString(pattern, -1) {
+		switch placeholder {
+		case PlaceholderTwoLettersCode, PlaceholderLocale, PlaceholderLocaleWithUnderscore,
+			PlaceholderAndroidCode, PlaceholderOSXCode, PlaceholderOSXLocale,
+			PlaceholderOriginalFileName, PlaceholderFileName, PlaceholderFileExtension, PlaceholderOriginalPath:
+		default:
+			return invalid("pattern %q has unknown placeholder %v", pattern, placeholder)
+		}
+	}
+
+	return nil
+}
+
+// expandPattern returns the path of the translation of the file to the language.
+// Mapping overrides the values of the placeholders by language, e.g. {"android_code": {"zh-CN": "zh-rCN"}}.
+func expandPattern(pattern, language, name string, mapping map[string]map[string]string) string {
+
+	twoLetters := strings.SplitN(language, "-", 2)[0]
+
+	locale := language
+	if !strings.Contains(language, "-") {
+		region, ok := localeRegions[language]
+		if !ok {
+			region = strings.ToUpper(language)
+		}
+		locale = language + "-" + region
+	}
+	region := strings.SplitN(locale, "-", 2)[1]
+
+	osxLocale := twoLetters
+	if strings.Contains(language, "-") {
+		osxLocale = language
+	}
+	if code, ok := osxCodes[language]; ok {
+		osxLocale = code
+	}
+
+	name = cleanName(name)
+	base := path.Base(name)
+	ext := path.Ext(base)
+	dir := path.Dir(name)
+	if dir == "." {
+		dir = ""
+	}
+
+	values := map[string]string{
+		PlaceholderTwoLettersCode:       twoLetters,
+		PlaceholderLocale:               locale,
+		PlaceholderLocaleWithUnderscore: strings.ReplaceAll(locale, "-", "_"),
+		PlaceholderAndroidCode:          twoLetters + "-r" + region,
+		PlaceholderOSXCode:              osxLocale + ".lproj",
+		PlaceholderOSXLocale:            osxLocale,
+		PlaceholderOriginalFileName:     base,
+		PlaceholderFileName:             strings.TrimSuffix(base, ext),
+		PlaceholderFileExtension:        strings.TrimPrefix(ext, "."),
+		PlaceholderOriginalPath:         dir,
+	}
+
+	result := placeholderPattern.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		if value, ok := mapping[strings.Trim(placeholder, "%")][language]; ok {
+			return value
+		}
+		return values[placeholder]
+	})
+
+	return cleanName(result)
+}
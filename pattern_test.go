@@ -0,0 +1,44 @@
+package crowdin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		language string
+		name     string
+		want     string
+	}{
+		{"values-%android_code%/%file_name%.xml", "ru", "ui/strings.csv", "values-ru-rRU/strings.xml"},
+		{"values-%android_code%/%file_name%.xml", "pt-BR", "strings.csv", "values-pt-rBR/strings.xml"},
+		{"%osx_code%/%original_file_name%", "zh-CN", "Localizable.strings", "zh-Hans.lproj/Localizable.strings"},
+		{"%osx_code%/%original_file_name%", "de", "Localizable.strings", "de.lproj/Localizable.strings"},
+		{"%locale%/%original_path%/%original_file_name%", "en", "/ui/menu.csv", "en-US/ui/menu.csv"},
+		{"%locale_with_underscore%/%original_path%/%file_name%.%file_extension%", "ja", "menu.csv", "ja_JP/menu.csv"},
+		{"Assets/%two_letters_code%/%file_name%.json", "pt-BR", "ui/menu.csv", "Assets/pt/menu.json"},
+		{"../%original_file_name%", "ru", "menu.csv", "menu.csv"},
+	}
+
+	for _, test := range tests {
+		if got := expandPattern(test.pattern, test.language, test.name, nil); got != test.want {
+			t.Errorf("%v %v: expected %v, got %v", test.pattern, test.language, test.want, got)
+		}
+	}
+
+	mapping := map[string]map[string]string{"android_code": {"ru": "ru"}}
+	if got := expandPattern("values-%android_code%/strings.xml", "ru", "strings.csv", mapping); got != "values-ru/strings.xml" {
+		t.Errorf("Expected %v, got %v", "values-ru/strings.xml", got)
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	if err := validatePattern("%osx_code%/%original_file_name%"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := validatePattern("%osx_cod%/%original_file_name%"); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected %v, got %v", ErrInvalidOptions, err)
+	}
+}
@@ -0,0 +1,91 @@
+package crowdin
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownProject is returned by ProjectPool for projects that weren't registered.
+var ErrUnknownProject = errors.New("crowdin: unknown project")
+
+// WithProject returns a new client of another project, that shares the http client,
+// endpoints, retry policy, middlewares and logging of this one.
+// Later changes of the settings of either client don't affect the other.
+func (crowdin *Crowdin) WithProject(token, project string) *Crowdin {
+
+	crowdin.mu.RLock()
+	defer crowdin.mu.RUnlock()
+
+	s := &Crowdin{}
+	s.config = crowdin.config
+	s.config.token = token
+	s.config.project = project
+	s.debug = crowdin.debug
+	s.logWriter = crowdin.logWriter
+	s.retryPolicy = crowdin.retryPolicy
+	s.middlewares = append([]Middleware(nil), crowdin.middlewares...)
+	s.logger = crowdin.logger
+	s.batch = crowdin.batch
+	s.cache = crowdin.cache
+	s.exportStore = crowdin.exportStore
+	return s
+}
+
+// ProjectPool holds credentials of many projects and hands out a client per project.
+// All the clients are created from one base client, so they share its transport and settings.
+// It's safe for concurrent use.
+type ProjectPool struct {
+	base *Crowdin
+
+	mu       sync.RWMutex
+	projects map[string]*Crowdin
+}
+
+// NewProjectPool - create new pool of projects. Clients of the projects are copies of base,
+// see WithProject. Base client should be configured before the projects are registered.
+func NewProjectPool(base *Crowdin) *ProjectPool {
+	return &ProjectPool{
+		base:     base,
+		projects: make(map[string]*Crowdin),
+	}
+}
+
+// Register adds the project to the pool, or replaces its key if it's already registered.
+func (pool *ProjectPool) Register(project, token string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.projects[project] = pool.base.WithProject(token, project)
+}
+
+// Remove removes the project from the pool.
+func (pool *ProjectPool) Remove(project string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	delete(pool.projects, project)
+}
+
+// Client returns the client of the project, or ErrUnknownProject if it wasn't registered.
+func (pool *ProjectPool) Client(project string) (*Crowdin, error) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	client, ok := pool.projects[project]
+	if !ok {
+		return nil, ErrUnknownProject
+	}
+	return client, nil
+}
+
+// Projects returns identifiers of the registered projects, sorted.
+func (pool *ProjectPool) Projects() []string {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	projects := make([]string, 0, len(pool.projects))
+	for project := range pool.projects {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects
+}
@@ -0,0 +1,85 @@
+package crowdin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProjectPool(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	keys := map[string]string{}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		project := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		mu.Lock()
+		keys[project] = r.URL.Query().Get("key")
+		mu.Unlock()
+		w.Write([]byte(`[]`))
+	})
+
+	pool := NewProjectPool(crowdin)
+	pool.Register("game-a", "key-a")
+	pool.Register("game-b", "key-b")
+
+	var wg sync.WaitGroup
+	for _, project := range pool.Projects() {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(project string) {
+				defer wg.Done()
+				client, err := pool.Client(project)
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+					return
+				}
+				if _, err := client.GetTranslationsStatus(); err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			}(project)
+		}
+	}
+	wg.Wait()
+
+	if keys["game-a"] != "key-a" || keys["game-b"] != "key-b" {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+
+	if _, err := pool.Client("game-c"); !errors.Is(err, ErrUnknownProject) {
+		t.Errorf("Expected %v, got %v", ErrUnknownProject, err)
+	}
+
+	a, _ := pool.Client("game-a")
+	if a.config.client != crowdin.config.client {
+		t.Errorf("Expected clients to share http client")
+	}
+}
+
+func TestCrowdin_concurrentSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			crowdin.SetProject("token", "project-name")
+			crowdin.SetDebug(false, nil)
+			crowdin.SetRetryPolicy(nil)
+		}()
+		go func() {
+			defer wg.Done()
+			crowdin.GetTranslationsStatus()
+		}()
+	}
+	wg.Wait()
+}
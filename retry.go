@@ -0,0 +1,210 @@
+package crowdin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how failed API calls are retried.
+// Transport errors, 429 and 5xx responses are retried, other responses are returned as is.
+// Calls that upload a FromReader source which isn't an io.Seeker are never retried.
+type RetryPolicy struct {
+	// Max number of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// Delay before the first retry. It's doubled on every following attempt.
+	MinBackoff time.Duration
+
+	// Upper bound of the delay between two attempts. Zero means no bound.
+	MaxBackoff time.Duration
+
+	// Part of the delay (from 0 to 1) that is randomized, so parallel clients don't retry at the same moment.
+	Jitter float64
+
+	// Retry-After header of 429/503 responses is used instead of the backoff when set.
+	// Values bigger than this limit make the call fail immediately. Zero means no limit.
+	MaxRetryAfter time.Duration
+
+	// By default endpoints that change the project structure (add-file, delete-project, add-directory...)
+	// are retried only when Crowdin surely didn't process the request: on 429 responses and connection failures.
+	// Set to true to retry them on any retryable failure.
+	RetryUnsafe bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for background jobs.
+// Calls asked to wait longer than MaxBackoff with Retry-After fail instead of blocking.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:   4,
+	MinBackoff:    500 * time.Millisecond,
+	MaxBackoff:    30 * time.Second,
+	Jitter:        0.2,
+	MaxRetryAfter: 30 * time.Second,
+}
+
+// unsafeEndpoints lists the calls that can't be blindly repeated,
+// because a second attempt fails or creates duplicates when the first one was processed.
+var unsafeEndpoints = map[string]bool{
+	"add-file":         true,
+	"delete-file":      true,
+	"create-project":   true,
+	"delete-project":   true,
+	"add-directory":    true,
+	"change-directory": true,
+	"delete-directory": true,
+}
+
+// SetRetryPolicy sets the retry policy of all the API calls. Nil disables retries.
+func (crowdin *Crowdin) SetRetryPolicy(policy *RetryPolicy) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
+	crowdin.retryPolicy = policy
+}
+
+// do sends the request built by newRequest through the middlewares and repeats it according to the retry policy.
+// newRequest is called for every attempt, so request bodies are never reused.
+func (crowdin *Crowdin) do(ctx context.Context, call *Request, newRequest func() (*http.Request, error)) (*http.Response, error) {
+
+	crowdin.mu.RLock()
+	policy := crowdin.retryPolicy
+	crowdin.mu.RUnlock()
+
+	handler := crowdin.handler()
+	endpoint := call.Endpoint
+
+	for attempt := 1; ; attempt++ {
+
+		httpReq, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		req := *call
+		req.Attempt = attempt
+		req.HTTP = httpReq
+
+		start := time.Now()
+		response, err := handler(&req)
+
+		// the body isn't read after the handler returns, even if a middleware answered without sending it,
+		// so closing it stops the goroutine that streams it
+		if httpReq.Body != nil {
+			httpReq.Body.Close()
+		}
+
+		err = redactError(err)
+		response = crowdin.logResponse(&req, response, err, start)
+
+		if policy == nil || call.oneShot || attempt >= policy.MaxAttempts || !policy.retryable(endpoint, response, err) {
+			return response, err
+		}
+
+		wait, ok := policy.delay(attempt, response)
+		if !ok {
+			return response, err
+		}
+
+		if response != nil {
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+			crowdin.log(fmt.Sprintf("Retrying %v in %v, status code: %v", endpoint, wait, response.StatusCode))
+		} else {
+			crowdin.log(fmt.Sprintf("Retrying %v in %v, error: %v", endpoint, wait, err))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (policy *RetryPolicy) retryable(endpoint string, response *http.Response, err error) bool {
+
+	if err != nil {
+		var formErr formError
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &formErr) {
+			return false
+		}
+		return policy.RetryUnsafe || !unsafeEndpoints[endpoint] || isDialError(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return policy.RetryUnsafe || !unsafeEndpoints[endpoint]
+	}
+
+	return false
+}
+
+// delay returns how long to wait before the next attempt.
+// It returns false when the server asks to wait longer than the policy allows.
+func (policy *RetryPolicy) delay(attempt int, response *http.Response) (time.Duration, bool) {
+
+	if response != nil {
+		if wait, ok := retryAfter(response.Header.Get("Retry-After")); ok {
+			if policy.MaxRetryAfter > 0 && wait > policy.MaxRetryAfter {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	wait := policy.MinBackoff
+	for i := 1; i < attempt && (policy.MaxBackoff <= 0 || wait < policy.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+		wait = policy.MaxBackoff
+	}
+
+	if policy.Jitter > 0 {
+		jitter := time.Duration(float64(wait) * policy.Jitter * rand.Float64())
+		wait = wait - time.Duration(float64(wait)*policy.Jitter/2) + jitter
+	}
+
+	return wait, true
+}
+
+// retryAfter parses the value of Retry-After header, which is either delay seconds or http date.
+func retryAfter(value string) (time.Duration, bool) {
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isDialError reports whether the request failed before reaching the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,174 @@
+package crowdin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_retriesServerErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	attempts := 0
+	mux.HandleFunc("/project-name/status", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`[{"name":"Russian","code":"ru"}]`))
+	})
+
+	status, err := crowdin.GetTranslationsStatus()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected %v, got %v", 3, attempts)
+	}
+	if len(status) != 1 || status[0].Code != "ru" {
+		t.Errorf("Expected %v, got %v", "ru", status)
+	}
+}
+
+func TestRetryPolicy_rebuildsMultipartBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2})
+
+	path := filepath.Join(t.TempDir(), "strings.csv")
+	os.WriteFile(path, []byte("id,text"), 0644)
+
+	var bodies []string
+	mux.HandleFunc("/project-name/update-file", func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("files[strings.csv]")
+		if err != nil {
+			t.Fatalf("Expected file in request, got %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		bodies = append(bodies, string(content))
+
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	_, err := crowdin.UpdateFile(&UpdateFileOptions{Files: map[string]string{"strings.csv": path}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != "id,text" || bodies[1] != "id,text" {
+		t.Errorf("Expected file content in both attempts, got %q", bodies)
+	}
+}
+
+func TestRetryPolicy_unsafeEndpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	attempts := 0
+	mux.HandleFunc("/project-name/delete-project", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := crowdin.DeleteProject(); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected %v, got %v", 1, attempts)
+	}
+}
+
+func TestRetryPolicy_oneShotSource(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+
+	attempts := 0
+	mux.HandleFunc("/project-name/update-file", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := crowdin.UpdateFile(&UpdateFileOptions{
+		Sources: map[string]FileSource{"a.csv": FromReader("a.csv", io.MultiReader(strings.NewReader("id,text")))},
+	})
+
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected %v, got %v", http.StatusServiceUnavailable, err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected %v, got %v", 1, attempts)
+	}
+}
+
+func TestDefaultRetryPolicy_retryAfter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&DefaultRetryPolicy)
+
+	attempts := 0
+	mux.HandleFunc("/project-name/status", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "1800")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	start := time.Now()
+	_, err := crowdin.GetTranslationsStatus()
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected %v, got %v", http.StatusTooManyRequests, err)
+	}
+	if attempts != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("Expected a single attempt without waiting, got %v in %v", attempts, time.Since(start))
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+	for _, test := range tests {
+		if got, _ := policy.delay(test.attempt, nil); got != test.want {
+			t.Errorf("Attempt %v: expected %v, got %v", test.attempt, test.want, got)
+		}
+	}
+
+	response := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if got, _ := policy.delay(1, response); got != 2*time.Minute {
+		t.Errorf("Expected %v, got %v", 2*time.Minute, got)
+	}
+
+	policy.MaxRetryAfter = time.Minute
+	if _, ok := policy.delay(1, response); ok {
+		t.Errorf("Expected Retry-After above the limit to be refused")
+	}
+}
@@ -0,0 +1,120 @@
+package crowdin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// FileSource is the content of a file uploaded to Crowdin.
+type FileSource interface {
+	// Name of the file, sent as the file name of the multipart form.
+	Name() string
+
+	// Open returns the content of the file. It's called once per request attempt.
+	Open() (io.ReadCloser, error)
+}
+
+// FromPath - File on the local file system.
+func FromPath(path string) FileSource {
+	return pathSource(path)
+}
+
+// FromFS - File from any file system, e.g. embed.FS or zip.Reader.
+func FromFS(fsys fs.FS, name string) FileSource {
+	return &fsSource{fsys: fsys, name: name}
+}
+
+// FromReader - In memory content with given file name.
+// Readers that implement io.Seeker are rewound for every attempt,
+// other readers can be sent only once, so the request is not retried.
+func FromReader(name string, r io.Reader) FileSource {
+	return &readerSource{name: name, r: r}
+}
+
+type pathSource string
+
+func (s pathSource) Name() string {
+	return string(s)
+}
+
+func (s pathSource) Open() (io.ReadCloser, error) {
+	return os.Open(string(s))
+}
+
+type fsSource struct {
+	fsys fs.FS
+	name string
+}
+
+func (s *fsSource) Name() string {
+	return s.name
+}
+
+func (s *fsSource) Open() (io.ReadCloser, error) {
+	return s.fsys.Open(s.name)
+}
+
+type readerSource struct {
+	name string
+	r    io.Reader
+
+	mu   sync.Mutex
+	read bool
+}
+
+var errReaderConsumed = errors.New("reader was already sent and can't be rewound")
+
+// oneShotSource is implemented by sources that can be read only once.
+// Calls that send them are never retried, see RetryPolicy.
+type oneShotSource interface {
+	oneShot() bool
+}
+
+func (s *readerSource) oneShot() bool {
+	_, ok := s.r.(io.Seeker)
+	return !ok
+}
+
+func (s *readerSource) Name() string {
+	return s.name
+}
+
+func (s *readerSource) Open() (io.ReadCloser, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.read {
+		seeker, ok := s.r.(io.Seeker)
+		if !ok {
+			return nil, fmt.Errorf("%v: %w", s.name, errReaderConsumed)
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	s.read = true
+
+	return ioutil.NopCloser(s.r), nil
+}
+
+// formFiles merges local paths and sources to the files of the form, with keys in the "files[name]" format.
+func formFiles(files map[string]string, sources map[string]FileSource) map[string]FileSource {
+
+	result := make(map[string]FileSource, len(files)+len(sources))
+
+	for k, path := range files {
+		result[fmt.Sprintf("files[%v]", k)] = FromPath(path)
+	}
+
+	for k, source := range sources {
+		result[fmt.Sprintf("files[%v]", k)] = source
+	}
+
+	return result
+}
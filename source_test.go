@@ -0,0 +1,67 @@
+package crowdin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestCrowdin_UploadTranslations_sources(t *testing.T) {
+	setup()
+	defer teardown()
+
+	crowdin.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+	fsys := fstest.MapFS{
+		"ru/menu.csv": &fstest.MapFile{Data: []byte("menu")},
+	}
+
+	attempts := 0
+	mux.HandleFunc("/project-name/upload-translation", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		for name, want := range map[string]string{"menu.csv": "menu", "dialogs.csv": "dialogs"} {
+			file, _, err := r.FormFile("files[" + name + "]")
+			if err != nil {
+				t.Fatalf("Expected %v in request, got %v", name, err)
+			}
+			content, _ := io.ReadAll(file)
+			if string(content) != want {
+				t.Errorf("Expected %v, got %v", want, string(content))
+			}
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	_, err := crowdin.UploadTranslations(&UploadTranslationsOptions{
+		Language: "ru",
+		Sources: map[string]FileSource{
+			"menu.csv":    FromFS(fsys, "ru/menu.csv"),
+			"dialogs.csv": FromReader("dialogs.csv", strings.NewReader("dialogs")),
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected %v, got %v", 2, attempts)
+	}
+}
+
+func TestFromReader_notSeekable(t *testing.T) {
+	source := FromReader("strings.csv", io.MultiReader(strings.NewReader("id")))
+
+	if _, err := source.Open(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := source.Open(); !errors.Is(err, errReaderConsumed) {
+		t.Errorf("Expected %v, got %v", errReaderConsumed, err)
+	}
+}
@@ -0,0 +1,413 @@
+package crowdin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncOptions are options for Sync call
+type SyncOptions struct {
+	// Local directory that is mirrored to the project, e.g. "localization/en".
+	LocalPath string
+
+	// Directory of the project the files are mirrored to. Root of the project by default.
+	Root string
+
+	// Globs of the files to mirror, relative to LocalPath, e.g. "**/*.csv". All the files by default.
+	// Syntax is the same as FileTree.Glob.
+	Include []string
+
+	// Globs of the files to skip, relative to LocalPath. Excluded remote files and directories are never deleted.
+	Exclude []string
+
+	// Delete remote files and directories that don't exist locally.
+	Delete bool
+
+	// Update only the existing files whose local modification time is after their last update in Crowdin,
+	// instead of all of them. It saves uploads, but misses changes that keep an older time, e.g. files
+	// restored with git checkout or copied with cp -p, and depends on the local and Crowdin clocks.
+	// Without it existing files are planned as SyncUpdateUnverified, since their changes are not known.
+	ModifiedOnly bool
+
+	// Only plan the changes, without applying them.
+	DryRun bool
+
+	// Type, scheme and header of the new files, see AddFileOptions.
+	Type                    FileType
+	Scheme                  Scheme
+	FirstLineContainsHeader bool
+}
+
+// SyncAction is a change made by Sync.
+type SyncAction string
+
+// Changes made by Sync, in the order they are applied.
+const (
+	SyncAddDirectory     SyncAction = "add-directory"
+	SyncAddFile          SyncAction = "add-file"
+	SyncUpdateFile       SyncAction = "update-file"
+	SyncUpdateUnverified SyncAction = "update-unverified"
+	SyncDeleteFile       SyncAction = "delete-file"
+	SyncDeleteDirectory  SyncAction = "delete-directory"
+)
+
+// SyncStep is a change of one file or directory.
+type SyncStep struct {
+	Action SyncAction
+
+	// Path in the project, e.g. "ui/menu.csv".
+	Path string
+
+	// Path of the local file, empty for directories and deleted files.
+	LocalPath string
+}
+
+// SyncPlan is the list of changes of Sync, in the order they are applied.
+// SyncUpdateFile steps are files modified after their last update in Crowdin, with ModifiedOnly.
+// SyncUpdateUnverified steps are all the other existing files, which are uploaded whether or not
+// they changed, so a dry run can't tell which of them will actually change.
+type SyncPlan struct {
+	Steps []SyncStep
+}
+
+// Empty reports whether the project is already in sync.
+func (plan *SyncPlan) Empty() bool {
+	return len(plan.Steps) == 0
+}
+
+// Paths returns the project paths of the steps of the action.
+func (plan *SyncPlan) Paths(action SyncAction) []string {
+	var paths []string
+	for _, step := range plan.Steps {
+		if step.Action == action {
+			paths = append(paths, step.Path)
+		}
+	}
+	return paths
+}
+
+// String returns the plan in a human readable form, a step per line.
+func (plan *SyncPlan) String() string {
+	var b strings.Builder
+	for _, step := range plan.Steps {
+		fmt.Fprintf(&b, "%-17s %v\n", step.Action, step.Path)
+	}
+	return b.String()
+}
+
+// Sync - Mirror the local directory to the project: create directories, add new files, update existing files
+// and optionally delete remote files that don't exist locally. Returns the plan of the changes,
+// which is applied unless DryRun is set.
+func (crowdin *Crowdin) Sync(options *SyncOptions) (*SyncPlan, error) {
+	return crowdin.SyncContext(context.Background(), options)
+}
+
+// SyncContext - Same as Sync, with a context.
+func (crowdin *Crowdin) SyncContext(ctx context.Context, options *SyncOptions) (*SyncPlan, error) {
+
+	if options == nil || options.LocalPath == "" {
+		return nil, invalid("LocalPath can't be empty")
+	}
+
+	if !options.Type.Valid() {
+		return nil, invalid("unknown file type %q", options.Type)
+	}
+
+	if err := options.Scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	local, err := localFiles(options)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := crowdin.GetProjectDetailsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := syncPlan(options, local, info.Files)
+	if options.DryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	settings := fileSettings{
+		Type:                    options.Type,
+		Scheme:                  options.Scheme,
+		FirstLineContainsHeader: options.FirstLineContainsHeader,
+	}
+
+	return plan, crowdin.applySync(ctx, plan, func(string) fileSettings { return settings })
+}
+
+// localFile is a file of the mirrored directory.
+type localFile struct {
+	path    string
+	modTime time.Time
+}
+
+// localFiles returns the files of the local directory that pass the filters, by relative path.
+func localFiles(options *SyncOptions) (map[string]localFile, error) {
+
+	files := make(map[string]localFile)
+	fsys := os.DirFS(options.LocalPath)
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !syncIncluded(options, name) {
+			return nil
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		files[name] = localFile{
+			path:    filepath.Join(options.LocalPath, filepath.FromSlash(name)),
+			modTime: fileInfo.ModTime(),
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// syncIncluded reports whether the file with the path relative to the root passes the filters.
+func syncIncluded(options *SyncOptions, name string) bool {
+
+	if syncExcluded(options, name) {
+		return false
+	}
+
+	if len(options.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range options.Include {
+		if matchGlob(cleanName(pattern), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// syncExcluded reports whether the path relative to the root matches one of the Exclude globs.
+func syncExcluded(options *SyncOptions, name string) bool {
+	for _, pattern := range options.Exclude {
+		if matchGlob(cleanName(pattern), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncPlan compares the local files with the project tree.
+func syncPlan(options *SyncOptions, local map[string]localFile, tree FileTree) *SyncPlan {
+
+	root := cleanName(options.Root)
+
+	// remote nodes under the root, by path relative to the root. Branches are never touched.
+	remote := make(map[string]*FileNode)
+	collect := func(name string, node *FileNode) error {
+		if node.NodeType == NodeBranch {
+			return fs.SkipDir
+		}
+		remote[name] = node
+		return nil
+	}
+
+	if root == "" {
+		tree.Walk(collect)
+	} else if node, ok := tree.Lookup(root); ok && node.IsDir() {
+		node.Files.Walk(collect)
+	}
+
+	projectPath := func(name string) string {
+		return path.Join(root, name)
+	}
+
+	plan := &SyncPlan{}
+
+	// directories of the new files, including the root itself
+	directories := make(map[string]bool)
+	for name := range local {
+		for dir := path.Dir(projectPath(name)); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			directories[dir] = true
+		}
+	}
+	for _, dir := range sortedKeys(directories) {
+		if _, ok := tree.Lookup(dir); !ok {
+			plan.Steps = append(plan.Steps, SyncStep{Action: SyncAddDirectory, Path: dir})
+		}
+	}
+
+	var updates, unverified []SyncStep
+	for _, name := range sortedKeys(local) {
+		file := local[name]
+		node, ok := remote[name]
+		switch {
+		case !ok:
+			plan.Steps = append(plan.Steps, SyncStep{Action: SyncAddFile, Path: projectPath(name), LocalPath: file.path})
+		case !options.ModifiedOnly || node.LastUpdated.IsZero():
+			unverified = append(unverified, SyncStep{Action: SyncUpdateUnverified, Path: projectPath(name), LocalPath: file.path})
+		case file.modTime.After(node.LastUpdated.Time):
+			updates = append(updates, SyncStep{Action: SyncUpdateFile, Path: projectPath(name), LocalPath: file.path})
+		}
+	}
+	plan.Steps = append(plan.Steps, updates...)
+	plan.Steps = append(plan.Steps, unverified...)
+
+	if !options.Delete {
+		return plan
+	}
+
+	// remote files that are deleted, and directories that keep no files
+	deleted := make(map[string]bool)
+	emptied := make(map[string]bool)
+	kept := make(map[string]bool)
+	for name, node := range remote {
+		if node.IsDir() {
+			continue
+		}
+		if _, ok := local[name]; !ok && syncIncluded(options, name) {
+			deleted[name] = true
+			for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+				emptied[dir] = true
+			}
+			continue
+		}
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			kept[dir] = true
+		}
+	}
+	for name := range local {
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			kept[dir] = true
+		}
+	}
+
+	for _, name := range sortedKeys(deleted) {
+		plan.Steps = append(plan.Steps, SyncStep{Action: SyncDeleteFile, Path: projectPath(name)})
+	}
+
+	// directories left without files, that pass the filters or held only deleted files.
+	// Excluded directories are never deleted.
+	var dirs []string
+	for name, node := range remote {
+		if node.IsDir() && !kept[name] && !syncExcluded(options, name) && (syncIncluded(options, name) || emptied[name]) {
+			if fileInfo, err := os.Stat(filepath.Join(options.LocalPath, filepath.FromSlash(name))); err == nil && fileInfo.IsDir() {
+				continue
+			}
+			dirs = append(dirs, name)
+		}
+	}
+	// children before parents
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, name := range dirs {
+		plan.Steps = append(plan.Steps, SyncStep{Action: SyncDeleteDirectory, Path: projectPath(name)})
+	}
+
+	return plan
+}
+
+// fileSettings are the options of AddFile and UpdateFile that apply to a single file.
+type fileSettings struct {
+	Type                    FileType
+	Scheme                  Scheme
+	FirstLineContainsHeader bool
+}
+
+// applySync applies the steps of the plan with the file and directory calls.
+// Files are added and updated with one call per distinct settings.
+func (crowdin *Crowdin) applySync(ctx context.Context, plan *SyncPlan, settings func(name string) fileSettings) error {
+
+	var groups []fileSettings
+	adds := make(map[fileSettings]*AddFileOptions)
+	updates := make(map[fileSettings]*UpdateFileOptions)
+
+	for _, step := range plan.Steps {
+		if step.Action != SyncAddFile && step.Action != SyncUpdateFile && step.Action != SyncUpdateUnverified {
+			continue
+		}
+
+		s := settings(step.Path)
+		if _, ok := adds[s]; !ok {
+			groups = append(groups, s)
+			adds[s] = &AddFileOptions{
+				Type:                    s.Type,
+				Scheme:                  s.Scheme,
+				FirstLineContainsHeader: s.FirstLineContainsHeader,
+				Files:                   make(map[string]string),
+			}
+			updates[s] = &UpdateFileOptions{
+				Scheme:                  s.Scheme,
+				FirstLineContainsHeader: s.FirstLineContainsHeader,
+				Files:                   make(map[string]string),
+			}
+		}
+
+		if step.Action == SyncAddFile {
+			adds[s].Files[step.Path] = step.LocalPath
+		} else {
+			updates[s].Files[step.Path] = step.LocalPath
+		}
+	}
+
+	for _, dir := range plan.Paths(SyncAddDirectory) {
+		if _, err := crowdin.AddDirectoryContext(ctx, dir); err != nil && !errors.Is(err, ErrDirectoryExists) {
+			return err
+		}
+	}
+
+	for _, s := range groups {
+		if add := adds[s]; len(add.Files) > 0 {
+			if _, err := crowdin.AddFileContext(ctx, add); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, s := range groups {
+		if update := updates[s]; len(update.Files) > 0 {
+			if _, err := crowdin.UpdateFileContext(ctx, update); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range plan.Paths(SyncDeleteFile) {
+		if _, err := crowdin.DeleteFileContext(ctx, name); err != nil && !errors.Is(err, ErrFileNotFound) {
+			return err
+		}
+	}
+
+	for _, dir := range plan.Paths(SyncDeleteDirectory) {
+		if _, err := crowdin.DeleteDirectoryContext(ctx, dir); err != nil && !errors.Is(err, ErrDirectoryNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
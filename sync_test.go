@@ -0,0 +1,155 @@
+package crowdin
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSyncPlan(t *testing.T) {
+	tree := parseTree(t, `{"files":[
+		{"id":"1","name":"en","node_type":"directory","files":[
+			{"id":"2","name":"ui","node_type":"directory","files":[
+				{"id":"3","name":"menu.csv","node_type":"file","last_updated":"2017-06-06T12:44:56+0000"},
+				{"id":"4","name":"old.csv","node_type":"file","last_updated":"2017-06-06T12:44:56+0000"}
+			]},
+			{"id":"5","name":"stable.csv","node_type":"file","last_updated":"2017-06-06T12:44:56+0000"}
+		]},
+		{"id":"6","name":"release","node_type":"branch","files":[
+			{"id":"7","name":"store.csv","node_type":"file"}
+		]}
+	]}`)
+
+	updated := time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC)
+	local := map[string]localFile{
+		"ui/menu.csv":         {path: "local/ui/menu.csv", modTime: updated.Add(time.Hour)},
+		"ui/dialogs/quit.csv": {path: "local/ui/dialogs/quit.csv"},
+		"stable.csv":          {path: "local/stable.csv", modTime: updated.Add(-time.Hour)},
+	}
+
+	options := &SyncOptions{LocalPath: t.TempDir(), Root: "/en/"}
+	plan := syncPlan(options, local, tree)
+
+	want := "add-directory     en/ui/dialogs\n" +
+		"add-file          en/ui/dialogs/quit.csv\n" +
+		"update-unverified en/stable.csv\n" +
+		"update-unverified en/ui/menu.csv\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+	if plan.Steps[1].LocalPath != "local/ui/dialogs/quit.csv" {
+		t.Errorf("Expected %v, got %v", "local/ui/dialogs/quit.csv", plan.Steps[1].LocalPath)
+	}
+
+	options.ModifiedOnly = true
+	options.Delete = true
+	plan = syncPlan(options, local, tree)
+
+	want = "add-directory     en/ui/dialogs\n" +
+		"add-file          en/ui/dialogs/quit.csv\n" +
+		"update-file       en/ui/menu.csv\n" +
+		"delete-file       en/ui/old.csv\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+
+	// branches are never touched, even when syncing the whole project
+	plan = syncPlan(&SyncOptions{LocalPath: t.TempDir(), Delete: true}, nil, tree)
+	if paths := plan.Paths(SyncDeleteFile); len(paths) != 3 {
+		t.Errorf("Expected 3 deleted files, got %v", paths)
+	}
+}
+
+func TestSyncPlan_deleteDirectories(t *testing.T) {
+	tree := parseTree(t, `{"files":[
+		{"id":"1","name":"drafts","node_type":"directory","files":[
+			{"id":"2","name":"old","node_type":"directory","files":[]}
+		]},
+		{"id":"3","name":"legacy","node_type":"directory","files":[
+			{"id":"4","name":"help.csv","node_type":"file"}
+		]},
+		{"id":"5","name":"empty","node_type":"directory","files":[]},
+		{"id":"6","name":"docs","node_type":"directory","files":[
+			{"id":"7","name":"readme.md","node_type":"file"}
+		]},
+		{"id":"8","name":"menu.csv","node_type":"file"}
+	]}`)
+
+	dir := t.TempDir()
+	local := map[string]localFile{"menu.csv": {path: dir + "/menu.csv"}}
+
+	options := &SyncOptions{LocalPath: dir, Exclude: []string{"drafts/**"}, Delete: true}
+	plan := syncPlan(options, local, tree)
+
+	want := "update-unverified menu.csv\n" +
+		"delete-file       docs/readme.md\n" +
+		"delete-file       legacy/help.csv\n" +
+		"delete-directory  legacy\n" +
+		"delete-directory  empty\n" +
+		"delete-directory  docs\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+
+	// directories that don't pass Include are deleted only when all their files are
+	options.Include = []string{"**/*.csv"}
+	plan = syncPlan(options, local, tree)
+
+	want = "update-unverified menu.csv\n" +
+		"delete-file       legacy/help.csv\n" +
+		"delete-directory  legacy\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+}
+
+func TestCrowdin_Sync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "menu.csv"), []byte("play,Play\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not synced"), 0644)
+
+	mux.HandleFunc("/project-name/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"files":[{"name":"old.csv","node_type":"file"}]}`))
+	})
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("type") != "csv" {
+			t.Errorf("Expected %v, got %v", "csv", r.FormValue("type"))
+		}
+		if _, _, err := r.FormFile("files[menu.csv]"); err != nil {
+			t.Errorf("Expected menu.csv to be added, got %v", err)
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+	mux.HandleFunc("/project-name/delete-file", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("file") != "old.csv" {
+			t.Errorf("Expected %v, got %v", "old.csv", r.FormValue("file"))
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	plan, err := crowdin.Sync(&SyncOptions{LocalPath: dir, Include: []string{"*.csv"}, Delete: true, Type: FileTypeCSV})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := "add-file          menu.csv\n" +
+		"delete-file       old.csv\n"
+	if plan.String() != want {
+		t.Errorf("Expected plan\n%v\ngot\n%v", want, plan)
+	}
+}
+
+func TestCrowdin_Sync_missingLocalPath(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if _, err := crowdin.Sync(&SyncOptions{}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected %v, got %v", ErrInvalidOptions, err)
+	}
+}
@@ -0,0 +1,93 @@
+package crowdin
+
+import (
+	"sync"
+	"time"
+)
+
+// ExportInterval is the minimal interval between exports of a project, unless it's in an organization plan.
+const ExportInterval = 30 * time.Minute
+
+// ExportStore remembers the time of the last export of every project, see SetExportStore.
+// Implementations must be safe for concurrent use.
+type ExportStore interface {
+	// LastExport returns the time of the last export of the project, if there is one.
+	LastExport(project string) (time.Time, bool)
+
+	// SetLastExport stores the time of the last export of the project.
+	SetLastExport(project string, t time.Time) error
+}
+
+// SetExportStore sets the store of the exports. With a store, ExportTranslations doesn't call the API
+// within ExportInterval after the last build of the project, and returns ExportThrottled status instead.
+// Nil disables the throttling, which is the default, e.g. for organization plans.
+func (crowdin *Crowdin) SetExportStore(store ExportStore) {
+	crowdin.mu.Lock()
+	defer crowdin.mu.Unlock()
+	crowdin.exportStore = store
+}
+
+// MemoryExportStore is an ExportStore that lives as long as the process.
+type MemoryExportStore struct {
+	mu      sync.Mutex
+	exports map[string]time.Time
+}
+
+// NewMemoryExportStore - create new empty store in memory.
+func NewMemoryExportStore() *MemoryExportStore {
+	return &MemoryExportStore{exports: make(map[string]time.Time)}
+}
+
+// LastExport returns the time of the last export of the project, if there is one.
+func (s *MemoryExportStore) LastExport(project string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.exports[project]
+	return t, ok
+}
+
+// SetLastExport stores the time of the last export of the project.
+func (s *MemoryExportStore) SetLastExport(project string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exports[project] = t
+	return nil
+}
+
+// FileExportStore is an ExportStore stored in a json file, so it's shared by runs, e.g. of CI jobs.
+type FileExportStore struct {
+	path string
+
+	mu      sync.Mutex
+	exports map[string]time.Time
+}
+
+// NewFileExportStore - create the store at path. The file is created on the first export.
+func NewFileExportStore(path string) (*FileExportStore, error) {
+
+	s := &FileExportStore{path: path, exports: make(map[string]time.Time)}
+	if err := readJSONFile(path, &s.exports); err != nil {
+		return nil, err
+	}
+	if s.exports == nil {
+		s.exports = make(map[string]time.Time)
+	}
+
+	return s, nil
+}
+
+// LastExport returns the time of the last export of the project, if there is one.
+func (s *FileExportStore) LastExport(project string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.exports[project]
+	return t, ok
+}
+
+// SetLastExport stores the time of the last export of the project and writes the whole store to the file.
+func (s *FileExportStore) SetLastExport(project string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exports[project] = t
+	return writeJSONFile(s.path, s.exports)
+}
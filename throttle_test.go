@@ -0,0 +1,83 @@
+package crowdin
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCrowdin_ExportTranslations_throttled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	exports := 0
+	mux.HandleFunc("/project-name/export", func(w http.ResponseWriter, r *http.Request) {
+		exports++
+		w.Write([]byte(`{"success":{"status":"built"}}`))
+	})
+
+	store := NewMemoryExportStore()
+	crowdin.SetExportStore(store)
+
+	result, err := crowdin.ExportTranslations()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Success.Status != ExportBuilt {
+		t.Errorf("Expected %v, got %v", ExportBuilt, result.Success.Status)
+	}
+	last, ok := store.LastExport("project-name")
+	if !ok || time.Since(last) > time.Minute {
+		t.Errorf("Expected the export to be stored, got %v", last)
+	}
+
+	result, err = crowdin.ExportTranslations()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Success.Status != ExportThrottled || !result.ThrottledUntil.Equal(last.Add(ExportInterval)) {
+		t.Errorf("Expected throttled until %v, got %+v", last.Add(ExportInterval), result)
+	}
+	if exports != 1 {
+		t.Errorf("Expected %v, got %v", 1, exports)
+	}
+
+	waited, err := crowdin.ExportAndWait(nil)
+	if err != nil || waited.Status != ExportThrottled {
+		t.Errorf("Expected %v, got %+v, %v", ExportThrottled, waited, err)
+	}
+
+	store.SetLastExport("project-name", time.Now().Add(-ExportInterval))
+	if result, _ := crowdin.ExportTranslations(); result.Success.Status != ExportBuilt {
+		t.Errorf("Expected %v, got %v", ExportBuilt, result.Success.Status)
+	}
+	if exports != 2 {
+		t.Errorf("Expected %v, got %v", 2, exports)
+	}
+}
+
+func TestFileExportStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports.json")
+
+	store, err := NewFileExportStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	now := time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC)
+	if err := store.SetLastExport("game", now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	store, err = NewFileExportStore(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if last, ok := store.LastExport("game"); !ok || !last.Equal(now) {
+		t.Errorf("Expected %v, got %v", now, last)
+	}
+	if _, ok := store.LastExport("other"); ok {
+		t.Errorf("Expected no export")
+	}
+}
@@ -0,0 +1,231 @@
+package crowdin
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// NodeType is the type of a node of the project tree.
+type NodeType string
+
+// Types of nodes of the project tree.
+const (
+	NodeFile      NodeType = "file"
+	NodeDirectory NodeType = "directory"
+	NodeBranch    NodeType = "branch"
+)
+
+// FileNode is a file, directory or branch of the project tree.
+// Dates are set by GetProjectDetails, counts of strings by GetLanguageStatus.
+type FileNode struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	NodeType NodeType `json:"node_type"`
+
+	Created      Time `json:"created"`
+	LastUpdated  Time `json:"last_updated"`
+	LastAccessed Time `json:"last_accessed"`
+	LastRevision Int  `json:"last_revision"`
+
+	Phrases         Int `json:"phrases"`
+	Translated      Int `json:"translated"`
+	Approved        Int `json:"approved"`
+	Words           Int `json:"words"`
+	WordsTranslated Int `json:"words_translated"`
+	WordsApproved   Int `json:"words_approved"`
+
+	// Children of directories and branches.
+	Files FileTree `json:"files"`
+}
+
+// IsDir reports whether the node is a directory or a branch.
+func (node *FileNode) IsDir() bool {
+	return node.NodeType == NodeDirectory || node.NodeType == NodeBranch
+}
+
+// FileTree is the list of the top level nodes of the project.
+// Paths of the nodes are relative to the root, e.g. "ui/menu.csv". Branches are the top level directories of their files.
+type FileTree []FileNode
+
+// WalkFunc is called by Walk for every node with its path.
+// Returning fs.SkipDir from a directory skips its children, any other error stops the walk.
+type WalkFunc func(path string, node *FileNode) error
+
+// Walk - Call fn for every node of the tree, parents before children, in the order of the response.
+func (tree FileTree) Walk(fn WalkFunc) error {
+	err := tree.walk("", fn)
+	if err == fs.SkipDir {
+		return nil
+	}
+	return err
+}
+
+func (tree FileTree) walk(dir string, fn WalkFunc) error {
+
+	for i := range tree {
+
+		node := &tree[i]
+		name := path.Join(dir, node.Name)
+
+		err := fn(name, node)
+		if err == fs.SkipDir && node.IsDir() {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := node.Files.walk(name, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lookup - Find the node by its path, e.g. "ui/menu.csv" or "/ui/menu.csv".
+func (tree FileTree) Lookup(name string) (*FileNode, bool) {
+
+	name = cleanName(name)
+	if name == "" {
+		return nil, false
+	}
+
+	nodes := tree
+	segments := strings.Split(name, "/")
+
+	for i, segment := range segments {
+
+		var found *FileNode
+		for j := range nodes {
+			if nodes[j].Name == segment {
+				found = &nodes[j]
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, false
+		}
+		if i == len(segments)-1 {
+			return found, true
+		}
+		nodes = found.Files
+	}
+
+	return nil, false
+}
+
+// Paths returns the paths of all the files of the tree, without directories, in walk order.
+func (tree FileTree) Paths() []string {
+	return tree.Glob("**")
+}
+
+// Glob - Find the paths of the files that match the pattern, in walk order.
+// Pattern has the syntax of path.Match, and "**" matches any number of directories, e.g. "ui/**/*.csv".
+func (tree FileTree) Glob(pattern string) []string {
+
+	pattern = cleanName(pattern)
+
+	var result []string
+	tree.Walk(func(name string, node *FileNode) error {
+		if !node.IsDir() && matchGlob(pattern, name) {
+			result = append(result, name)
+		}
+		return nil
+	})
+
+	return result
+}
+
+// matchGlob reports whether the path matches the pattern, with "**" matching any number of segments.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+
+	for len(pattern) > 0 {
+
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// TreeDiff is the difference between two trees of the project.
+type TreeDiff struct {
+	// Files and directories that exist only in the newer tree.
+	Added []string
+
+	// Files and directories that exist only in the older tree.
+	Removed []string
+
+	// Files that exist in both trees, with different revisions, dates or counts of strings.
+	Changed []string
+}
+
+// Empty reports whether the trees are the same.
+func (diff TreeDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// Diff - Compare the tree with a newer tree of the same project.
+func (tree FileTree) Diff(newer FileTree) TreeDiff {
+
+	var diff TreeDiff
+
+	newer.Walk(func(name string, node *FileNode) error {
+		old, ok := tree.Lookup(name)
+		switch {
+		case !ok || old.IsDir() != node.IsDir():
+			diff.Added = append(diff.Added, name)
+		case !node.IsDir() && !sameNode(old, node):
+			diff.Changed = append(diff.Changed, name)
+		}
+		return nil
+	})
+
+	tree.Walk(func(name string, node *FileNode) error {
+		if current, ok := newer.Lookup(name); !ok || current.IsDir() != node.IsDir() {
+			diff.Removed = append(diff.Removed, name)
+		}
+		return nil
+	})
+
+	return diff
+}
+
+// sameNode compares the attributes of the nodes, without their children.
+func sameNode(a, b *FileNode) bool {
+	return a.LastRevision == b.LastRevision &&
+		a.LastUpdated.Equal(b.LastUpdated.Time) &&
+		a.Phrases == b.Phrases &&
+		a.Translated == b.Translated &&
+		a.Approved == b.Approved &&
+		a.Words == b.Words &&
+		a.WordsTranslated == b.WordsTranslated &&
+		a.WordsApproved == b.WordsApproved
+}
+
+// cleanName returns the path of the file in the project without leading slash, e.g. "ui/menu.csv".
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
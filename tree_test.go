@@ -0,0 +1,122 @@
+package crowdin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+const infoFiles = `{"files":[
+	{"id":"1","name":"ui","node_type":"directory","files":[
+		{"id":"2","name":"menu.csv","node_type":"file","last_updated":"2017-06-06T12:44:56+0000","last_revision":"3"},
+		{"id":"3","name":"dialogs","node_type":"directory","files":[
+			{"id":"4","name":"quit.csv","node_type":"file","last_revision":"1"}
+		]}
+	]},
+	{"id":"5","name":"release","node_type":"branch","files":[
+		{"id":"6","name":"store.csv","node_type":"file","last_revision":"1"}
+	]},
+	{"id":"7","name":"readme.md","node_type":"file","last_revision":"2"}
+]}`
+
+func parseTree(t *testing.T, data string) FileTree {
+	var info ProjectInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return info.Files
+}
+
+func TestFileTree_Lookup(t *testing.T) {
+	tree := parseTree(t, infoFiles)
+
+	node, ok := tree.Lookup("/ui/dialogs/quit.csv")
+	if !ok || node.ID != "4" {
+		t.Errorf("Expected %v, got %v", "4", node)
+	}
+	if node, ok := tree.Lookup("ui/dialogs"); !ok || !node.IsDir() {
+		t.Errorf("Expected directory, got %v", node)
+	}
+	if _, ok := tree.Lookup("ui/missing.csv"); ok {
+		t.Errorf("Expected missing file")
+	}
+	if node, _ := tree.Lookup("ui/menu.csv"); node.LastRevision != 3 || node.LastUpdated.Year() != 2017 {
+		t.Errorf("Unexpected %+v", node)
+	}
+}
+
+func TestFileTree_Walk(t *testing.T) {
+	tree := parseTree(t, infoFiles)
+
+	var paths []string
+	tree.Walk(func(path string, node *FileNode) error {
+		paths = append(paths, path)
+		if path == "ui/dialogs" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+
+	want := "[ui ui/menu.csv ui/dialogs release release/store.csv readme.md]"
+	if fmt.Sprint(paths) != want {
+		t.Errorf("Expected %v, got %v", want, paths)
+	}
+}
+
+func TestFileTree_Glob(t *testing.T) {
+	tree := parseTree(t, infoFiles)
+
+	tests := map[string]string{
+		"**/*.csv":  "[ui/menu.csv ui/dialogs/quit.csv release/store.csv]",
+		"ui/*.csv":  "[ui/menu.csv]",
+		"ui/**":     "[ui/menu.csv ui/dialogs/quit.csv]",
+		"*.md":      "[readme.md]",
+		"**/q?it.*": "[ui/dialogs/quit.csv]",
+	}
+
+	for pattern, want := range tests {
+		if got := fmt.Sprint(tree.Glob(pattern)); got != want {
+			t.Errorf("%v: expected %v, got %v", pattern, want, got)
+		}
+	}
+}
+
+func TestFileTree_Diff(t *testing.T) {
+	older := parseTree(t, infoFiles)
+	newer := parseTree(t, `{"files":[
+		{"id":"1","name":"ui","node_type":"directory","files":[
+			{"id":"2","name":"menu.csv","node_type":"file","last_updated":"2017-06-06T12:44:56+0000","last_revision":"4"},
+			{"id":"8","name":"store.csv","node_type":"file"}
+		]},
+		{"id":"7","name":"readme.md","node_type":"file","last_revision":"2"}
+	]}`)
+
+	diff := older.Diff(newer)
+	if fmt.Sprint(diff.Added) != "[ui/store.csv]" {
+		t.Errorf("Expected %v, got %v", "[ui/store.csv]", diff.Added)
+	}
+	if fmt.Sprint(diff.Removed) != "[ui/dialogs ui/dialogs/quit.csv release release/store.csv]" {
+		t.Errorf("Unexpected removed %v", diff.Removed)
+	}
+	if fmt.Sprint(diff.Changed) != "[ui/menu.csv]" {
+		t.Errorf("Expected %v, got %v", "[ui/menu.csv]", diff.Changed)
+	}
+	if !older.Diff(older).Empty() {
+		t.Errorf("Expected empty diff, got %+v", older.Diff(older))
+	}
+}
+
+func TestLanguageStatus_tree(t *testing.T) {
+	var status LanguageStatus
+	data := `{"files":[{"id":"1","name":"ui","node_type":"directory","files":[
+		{"id":"2","name":"menu.csv","node_type":"file","phrases":"10","translated":"4"}]}]}`
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	node, ok := status.Files.Lookup("ui/menu.csv")
+	if !ok || node.Phrases != 10 || node.Translated != 4 {
+		t.Errorf("Unexpected %+v", node)
+	}
+}
@@ -0,0 +1,62 @@
+package crowdin
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Int is a number of a response. Crowdin sends numbers both as json numbers and as strings,
+// so both forms are accepted. Empty string and null are decoded as zero.
+type Int int
+
+// UnmarshalJSON decodes quoted and bare numbers.
+func (i *Int) UnmarshalJSON(data []byte) error {
+
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*i = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("crowdin: invalid number %q", data)
+	}
+
+	*i = Int(n)
+	return nil
+}
+
+// timeLayouts are the formats of the dates sent by Crowdin.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Time is a date of a response. Empty string and null are decoded as zero time.
+type Time struct {
+	time.Time
+}
+
+// UnmarshalJSON decodes the dates in any of the formats used by Crowdin.
+func (t *Time) UnmarshalJSON(data []byte) error {
+
+	value := string(bytes.Trim(data, `"`))
+	if value == "" || value == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("crowdin: invalid date %q", value)
+}
@@ -0,0 +1,46 @@
+package crowdin
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestInt_UnmarshalJSON(t *testing.T) {
+	var status TranslationStatus
+	data := `{"phrases":"120","translated":85,"approved":"","words":null,"translated_progress":"70"}`
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status.Phrases != 120 || status.Translated != 85 || status.Approved != 0 || status.Words != 0 || status.TranslatedProgress != 70 {
+		t.Errorf("Unexpected %+v", status)
+	}
+
+	if err := json.Unmarshal([]byte(`{"phrases":"many"}`), &status); err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+	}{
+		{`"2017-06-06T12:44:56+0000"`, time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC)},
+		{`"2017-06-06T12:44:56Z"`, time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC)},
+		{`"2017-06-06 12:44:56"`, time.Date(2017, 6, 6, 12, 44, 56, 0, time.UTC)},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+	}
+
+	for _, test := range tests {
+		var got Time
+		if err := json.Unmarshal([]byte(test.data), &got); err != nil {
+			t.Errorf("%v: expected no error, got %v", test.data, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%v: expected %v, got %v", test.data, test.want, got)
+		}
+	}
+}
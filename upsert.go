@@ -0,0 +1,149 @@
+package crowdin
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sort"
+)
+
+// AddOrUpdateFileOptions are options for AddOrUpdateFile call
+type AddOrUpdateFileOptions struct {
+	// Type of the new files, see AddFileOptions.
+	Type FileType
+
+	// Scheme of CSV files, see AddFileOptions.
+	Scheme Scheme
+
+	// Used when uploading CSV (or XLS/XLSX) files. Defines whether first line contains columns headers.
+	FirstLineContainsHeader bool
+
+	// Files that should be added to or updated in Crowdin project. Keys are file names with path in Crowdin project.
+	Files map[string]string
+
+	// Same as Files, but the content is read from any source, e.g. FromReader or FromFS.
+	Sources map[string]FileSource
+}
+
+// AddOrUpdateFileResult is a result of AddOrUpdateFile
+type AddOrUpdateFileResult struct {
+	// Directories created for the new files, parents first.
+	Directories []string
+
+	// Result of the new files, nil if all the files already existed.
+	Added *AddFileResult
+
+	// Result of the existing files, nil if all the files were new.
+	Updated *UpdateFileResult
+}
+
+// AddOrUpdateFile - Add new files and update existing ones, creating missing directories of the new files.
+func (crowdin *Crowdin) AddOrUpdateFile(options *AddOrUpdateFileOptions) (*AddOrUpdateFileResult, error) {
+	return crowdin.AddOrUpdateFileContext(context.Background(), options)
+}
+
+// AddOrUpdateFileContext - Same as AddOrUpdateFile, with a context.
+func (crowdin *Crowdin) AddOrUpdateFileContext(ctx context.Context, options *AddOrUpdateFileOptions) (*AddOrUpdateFileResult, error) {
+
+	if options == nil {
+		return nil, invalid("Files can't be empty")
+	}
+
+	if err := (&AddFileOptions{Type: options.Type, Scheme: options.Scheme, Files: options.Files, Sources: options.Sources}).Validate(); err != nil {
+		return nil, err
+	}
+
+	info, err := crowdin.GetProjectDetailsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool)
+	directories := make(map[string]bool)
+	info.Files.Walk(func(name string, node *FileNode) error {
+		if node.IsDir() {
+			directories[name] = true
+		} else {
+			files[name] = true
+		}
+		return nil
+	})
+
+	add := &AddFileOptions{
+		Type:                    options.Type,
+		Scheme:                  options.Scheme,
+		FirstLineContainsHeader: options.FirstLineContainsHeader,
+		Files:                   make(map[string]string),
+		Sources:                 make(map[string]FileSource),
+	}
+	update := &UpdateFileOptions{
+		Scheme:                  options.Scheme,
+		FirstLineContainsHeader: options.FirstLineContainsHeader,
+		Files:                   make(map[string]string),
+		Sources:                 make(map[string]FileSource),
+	}
+
+	for name, local := range options.Files {
+		if files[cleanName(name)] {
+			update.Files[name] = local
+		} else {
+			add.Files[name] = local
+		}
+	}
+
+	for name, source := range options.Sources {
+		if files[cleanName(name)] {
+			update.Sources[name] = source
+		} else {
+			add.Sources[name] = source
+		}
+	}
+
+	result := &AddOrUpdateFileResult{}
+
+	for _, dir := range missingDirectories(add, directories) {
+		_, err := crowdin.AddDirectoryContext(ctx, dir)
+		if err != nil && !errors.Is(err, ErrDirectoryExists) {
+			return result, err
+		}
+		result.Directories = append(result.Directories, dir)
+	}
+
+	var addErr, updateErr error
+
+	if len(add.Files) > 0 || len(add.Sources) > 0 {
+		result.Added, addErr = crowdin.AddFileContext(ctx, add)
+	}
+
+	if len(update.Files) > 0 || len(update.Sources) > 0 {
+		result.Updated, updateErr = crowdin.UpdateFileContext(ctx, update)
+	}
+
+	return result, errors.Join(addErr, updateErr)
+}
+
+// missingDirectories returns the directories of the new files that don't exist yet, parents first.
+func missingDirectories(add *AddFileOptions, directories map[string]bool) []string {
+
+	missing := make(map[string]bool)
+	check := func(name string) {
+		for dir := path.Dir(cleanName(name)); dir != "." && !directories[dir]; dir = path.Dir(dir) {
+			missing[dir] = true
+		}
+	}
+
+	for name := range add.Files {
+		check(name)
+	}
+	for name := range add.Sources {
+		check(name)
+	}
+
+	result := make([]string, 0, len(missing))
+	for dir := range missing {
+		result = append(result, dir)
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,85 @@
+package crowdin
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMissingDirectories(t *testing.T) {
+	add := &AddFileOptions{
+		Files: map[string]string{
+			"ui/menu.csv":          "menu.csv",
+			"/ui/dialogs/quit.csv": "quit.csv",
+			"readme.md":            "readme.md",
+		},
+		Sources: map[string]FileSource{
+			"store/items/potions.csv": FromReader("potions.csv", nil),
+		},
+	}
+
+	got := missingDirectories(add, map[string]bool{"ui": true})
+
+	want := []string{"store", "store/items", "ui/dialogs"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestCrowdin_AddOrUpdateFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	dir := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"ui/menu.csv", "ui/dialogs/quit.csv"} {
+		path := filepath.Join(dir, filepath.Base(name))
+		os.WriteFile(path, []byte(name), 0644)
+		files[name] = path
+	}
+
+	mux.HandleFunc("/project-name/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"files":[{"name":"ui","node_type":"directory","files":[{"name":"menu.csv","node_type":"file"}]}]}`))
+	})
+	mux.HandleFunc("/project-name/add-directory", func(w http.ResponseWriter, r *http.Request) {
+		if name := r.FormValue("name"); name != "ui/dialogs" {
+			t.Errorf("Expected %v, got %v", "ui/dialogs", name)
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := r.FormFile("files[ui/dialogs/quit.csv]"); err != nil {
+			t.Errorf("Expected ui/dialogs/quit.csv to be added, got %v", err)
+		}
+		if _, _, err := r.FormFile("files[ui/menu.csv]"); err == nil {
+			t.Errorf("Expected ui/menu.csv not to be added")
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+	mux.HandleFunc("/project-name/update-file", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := r.FormFile("files[ui/menu.csv]"); err != nil {
+			t.Errorf("Expected ui/menu.csv to be updated, got %v", err)
+		}
+		if _, _, err := r.FormFile("files[ui/dialogs/quit.csv]"); err == nil {
+			t.Errorf("Expected ui/dialogs/quit.csv not to be updated")
+		}
+		w.Write([]byte(`{"success":true,"files":{"ui/menu.csv":"updated"}}`))
+	})
+
+	result, err := crowdin.AddOrUpdateFile(&AddOrUpdateFileOptions{Files: files})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if fmt.Sprint(result.Directories) != "[ui/dialogs]" {
+		t.Errorf("Expected %v, got %v", "[ui/dialogs]", result.Directories)
+	}
+	if result.Added == nil || !result.Added.Success {
+		t.Errorf("Expected added files, got %+v", result.Added)
+	}
+	if result.Updated == nil || result.Updated.Files["ui/menu.csv"] != "updated" {
+		t.Errorf("Expected updated ui/menu.csv, got %+v", result.Updated)
+	}
+}
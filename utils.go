@@ -1,7 +1,8 @@
 package crowdin
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,36 +10,90 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 )
 
 type postOptions struct {
+	endpoint    string
 	urlStr      string
 	params      map[string]string
 	paramsArray map[string][]string
-	files       map[string]string
+	files       map[string]FileSource
 }
 
+// maxErrorBody limits how much of a failed download is read to decode the error.
+const maxErrorBody = 64 << 10
+
 type getOptions struct {
-	urlStr string
-	params map[string]string
+	endpoint string
+	urlStr   string
+	params   map[string]string
 }
 
 // params - extra params
 // fileNames - key = dir
-func (crowdin *Crowdin) post(options *postOptions) ([]byte, error) {
+func (crowdin *Crowdin) post(ctx context.Context, options *postOptions) ([]byte, error) {
+
+	call := newRequest(options.endpoint, options.params, options.paramsArray, options.files)
+
+	response, err := crowdin.do(ctx, call, func() (*http.Request, error) {
+
+		// the body is streamed, so files are read only while the request is being sent,
+		// and a new pipe is created for every attempt
+		pipeReader, pipeWriter := io.Pipe()
+		writer := multipart.NewWriter(pipeWriter)
+
+		go func() {
+			if err := writeForm(ctx, writer, options); err != nil {
+				pipeWriter.CloseWithError(formError{err})
+				return
+			}
+			pipeWriter.Close()
+		}()
+
+		req, err := http.NewRequestWithContext(ctx, "POST", options.urlStr, pipeReader)
+		if err != nil {
+			pipeReader.Close()
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		crowdin.setHeaders(req)
+		return req, nil
+	})
+	if err != nil {
+		var formErr formError
+		if errors.As(err, &formErr) {
+			return nil, formErr.err
+		}
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	bodyResponse, err := ioutil.ReadAll(&contextReader{ctx: ctx, r: response.Body})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkResponse(options.endpoint, response.StatusCode, bodyResponse); err != nil {
+		return bodyResponse, err
+	}
+
+	return bodyResponse, nil
+}
 
-	var buffer bytes.Buffer
-	writer := multipart.NewWriter(&buffer)
+// writeForm writes params and files of the options to the multipart writer and closes it.
+func writeForm(ctx context.Context, writer *multipart.Writer, options *postOptions) error {
 
 	if options.params != nil {
 		for k, v := range options.params {
 			fw, err := writer.CreateFormField(k)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if _, err = fw.Write([]byte(v)); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
@@ -48,118 +103,224 @@ func (crowdin *Crowdin) post(options *postOptions) ([]byte, error) {
 			for _, v := range arr {
 				fw, err := writer.CreateFormField(k)
 				if err != nil {
-					return nil, err
+					return err
 				}
 				if _, err = fw.Write([]byte(v)); err != nil {
-					return nil, err
+					return err
 				}
 			}
 		}
 	}
 
 	if options.files != nil {
-		for key, filePath := range options.files {
-			file, err := os.Open(filePath)
-			if err != nil {
-				return nil, err
+		for key, source := range options.files {
+			if err := writeFile(ctx, writer, key, source); err != nil {
+				return err
 			}
+		}
+	}
 
-			defer file.Close()
+	return writer.Close()
+}
 
-			fw, err := writer.CreateFormFile(key, filePath)
-			if err != nil {
-				return nil, err
-			}
-			if _, err = io.Copy(fw, file); err != nil {
-				return nil, err
-			}
+// writeFile opens the file only when it's its turn to be sent, and closes it right after.
+func writeFile(ctx context.Context, writer *multipart.Writer, key string, source FileSource) error {
 
-		}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	writer.Close()
+	file, err := source.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
 
-	req, err := http.NewRequest("POST", options.urlStr, &buffer)
+	fw, err := writer.CreateFormFile(key, source.Name())
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	response, err := crowdin.config.client.Do(req)
+	_, err = io.Copy(fw, &contextReader{ctx: ctx, r: file})
+	return err
+}
+
+// formError is an error of building the request body, e.g. missing file.
+// Such errors are never retried.
+type formError struct {
+	err error
+}
+
+func (e formError) Error() string {
+	return e.err.Error()
+}
+
+func (e formError) Unwrap() error {
+	return e.err
+}
+
+func (crowdin *Crowdin) get(ctx context.Context, options *getOptions) ([]byte, error) {
+
+	response, err := crowdin.getResponse(ctx, options)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
-	bodyResponse, err := ioutil.ReadAll(response.Body)
+	bodyResponse, err := ioutil.ReadAll(&contextReader{ctx: ctx, r: response.Body})
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return bodyResponse, APIError{What: fmt.Sprintf("Status code: %v", response.StatusCode)}
+	if err := checkResponse(options.endpoint, response.StatusCode, bodyResponse); err != nil {
+		return bodyResponse, err
 	}
 
 	return bodyResponse, nil
 }
 
-func (crowdin *Crowdin) get(options *getOptions) ([]byte, error) {
+func (crowdin *Crowdin) getResponse(ctx context.Context, options *getOptions) (*http.Response, error) {
+
+	urlStr := options.urlStr
+	if options.params != nil {
+		for k, v := range options.params {
+			urlStr += "&" + k + "=" + v
+		}
+	}
+
+	call := newRequest(options.endpoint, options.params, nil, nil)
+
+	return crowdin.do(ctx, call, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		crowdin.setHeaders(req)
+		return req, nil
+	})
+}
+
+func (crowdin *Crowdin) setHeaders(req *http.Request) {
+	crowdin.mu.RLock()
+	userAgent := crowdin.config.userAgent
+	crowdin.mu.RUnlock()
+
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+}
 
-	response, err := crowdin.getResponse(options)
+// projectURL returns the url of the endpoint of project API, with the project key.
+func (crowdin *Crowdin) projectURL(endpoint string) string {
+	crowdin.mu.RLock()
+	defer crowdin.mu.RUnlock()
+	return fmt.Sprintf(crowdin.config.apiBaseURL+"%v/%v?key=%v", crowdin.config.project, endpoint, crowdin.config.token)
+}
+
+// accountURL returns the url of the endpoint of account API.
+func (crowdin *Crowdin) accountURL(endpoint, accountKey string) string {
+	crowdin.mu.RLock()
+	defer crowdin.mu.RUnlock()
+	return fmt.Sprintf(crowdin.config.apiAccountBaseURL+"%v?account-key=%v", endpoint, accountKey)
+}
+
+// download streams the body of the response to w.
+// Nothing is written when the call fails, the error payload is decoded instead.
+func (crowdin *Crowdin) download(ctx context.Context, w io.Writer, options *getOptions) error {
+
+	response, err := crowdin.getResponse(ctx, options)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
-	bodyResponse, err := ioutil.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		bodyResponse, err := ioutil.ReadAll(&contextReader{ctx: ctx, r: io.LimitReader(response.Body, maxErrorBody)})
+		if err != nil {
+			return err
+		}
+		return checkResponse(options.endpoint, response.StatusCode, bodyResponse)
+	}
+
+	_, err = io.Copy(w, &contextReader{ctx: ctx, r: response.Body})
+	return err
+}
+
+// createFile writes the file at path atomically: the content is written to a temporary file
+// in the same directory, which replaces path only if write succeeds. So path never holds
+// an error page or a partial download, and its previous content is kept on failure.
+func createFile(path string, write func(w io.Writer) error) error {
+
+	temp, err := writeTemp(path, write)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return bodyResponse, APIError{What: fmt.Sprintf("Status code: %v", response.StatusCode)}
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return err
 	}
 
-	return bodyResponse, nil
+	return nil
 }
 
-func (crowdin *Crowdin) getResponse(options *getOptions) (*http.Response, error) {
+// writeTemp writes the content to a new temporary file next to path, and returns its name.
+func writeTemp(path string, write func(w io.Writer) error) (string, error) {
 
-	if options != nil && options.params != nil {
-		for k, v := range options.params {
-			options.urlStr += "&" + k + "=" + v
-		}
+	if path == "" {
+		return "", errors.New("LocalPath can't be empty")
 	}
 
-	req, err := http.NewRequest("GET", options.urlStr, nil)
+	out, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	err = write(out)
+	if err == nil {
+		err = out.Chmod(0o644)
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
 	}
 
-	response, err := crowdin.config.client.Do(req)
 	if err != nil {
-		return nil, err
+		os.Remove(out.Name())
+		return "", err
 	}
 
-	return response, nil
+	return out.Name(), nil
 }
 
-func (crowdin *Crowdin) log(a interface{}) {
-	if crowdin.debug {
-		log.Println(a)
-		if crowdin.logWriter != nil {
-			timestamp := time.Now().Format(time.RFC3339)
-			msg := fmt.Sprintf("%v: %v", timestamp, a)
-			fmt.Fprintln(crowdin.logWriter, msg)
-		}
-	}
+// contextReader stops reading as soon as ctx is done, so long copies
+// of files and response bodies can be cancelled between chunks.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
 }
 
-// APIError holds data of errors returned from the API.
-type APIError struct {
-	What string
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
 }
 
-func (e APIError) Error() string {
-	return fmt.Sprintf("%v", e.What)
+func (crowdin *Crowdin) log(a interface{}) {
+	crowdin.mu.RLock()
+	debug, logWriter := crowdin.debug, crowdin.logWriter
+	crowdin.mu.RUnlock()
+
+	if debug {
+		msg := redactBody(fmt.Sprint(a))
+		log.Println(msg)
+		if logWriter != nil {
+			timestamp := time.Now().Format(time.RFC3339)
+			fmt.Fprintf(logWriter, "%v: %v\n", timestamp, msg)
+		}
+	}
 }
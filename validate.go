@@ -0,0 +1,171 @@
+package crowdin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidOptions is returned, wrapped with the details, when options of a call are rejected
+// before the request is sent.
+var ErrInvalidOptions = errors.New("crowdin: invalid options")
+
+func invalid(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %v", ErrInvalidOptions, fmt.Sprintf(format, a...))
+}
+
+// Validate - Check the options before AddFile call.
+func (options *AddFileOptions) Validate() error {
+
+	if options == nil {
+		return invalid("Files can't be empty")
+	}
+
+	if !options.Type.Valid() {
+		return invalid("unknown file type %q", options.Type)
+	}
+
+	if err := options.Scheme.Validate(); err != nil {
+		return err
+	}
+
+	return validateFiles(options.Files, options.Sources)
+}
+
+// Validate - Check the options before UpdateFile call.
+func (options *UpdateFileOptions) Validate() error {
+
+	if options == nil {
+		return invalid("Files can't be empty")
+	}
+
+	if err := options.Scheme.Validate(); err != nil {
+		return err
+	}
+
+	return validateFiles(options.Files, options.Sources)
+}
+
+// Validate - Check the options before UploadTranslations call.
+func (options *UploadTranslationsOptions) Validate() error {
+
+	if options == nil || options.Language == "" {
+		return invalid("Language can't be empty")
+	}
+
+	switch options.ImportDuplicates {
+	case "", "0", "1":
+	default:
+		return invalid("ImportDuplicates should be 0 or 1, got %q", options.ImportDuplicates)
+	}
+
+	return validateFiles(options.Files, options.Sources)
+}
+
+// Validate - Check the options before CreateProject call.
+func (options *CreateProjectOptions) Validate() error {
+
+	if options == nil || options.Name == "" {
+		return invalid("Name can't be empty")
+	}
+
+	if options.Identifier == "" {
+		return invalid("Identifier can't be empty")
+	}
+
+	if options.SourceLanguage == "" {
+		return invalid("SourceLanguage can't be empty")
+	}
+
+	if !options.JoinPolicy.Valid() {
+		return invalid("unknown join policy %q, should be %q or %q", options.JoinPolicy, JoinPolicyOpen, JoinPolicyPrivate)
+	}
+
+	return nil
+}
+
+// Validate - Check the options before EditProject call.
+func (options *EditProjectOptions) Validate() error {
+
+	if options != nil && !options.JoinPolicy.Valid() {
+		return invalid("unknown join policy %q, should be %q or %q", options.JoinPolicy, JoinPolicyOpen, JoinPolicyPrivate)
+	}
+
+	return nil
+}
+
+// Validate - Check the options before ChangeDirectory call.
+func (options *ChangeDirectoryOptions) Validate() error {
+
+	if options == nil || options.Name == "" {
+		return invalid("Name can't be empty")
+	}
+
+	return nil
+}
+
+// Validate - Check that all the columns are known and that the scheme has an identifier
+// or a source column. Empty scheme is valid, it's required only for CSV files.
+func (s Scheme) Validate() error {
+
+	if s == "" {
+		return nil
+	}
+
+	seen := make(map[SchemeColumn]bool)
+	hasKey := false
+
+	for i, column := range s.Columns() {
+
+		if column == "" {
+			return invalid("scheme %q has empty column %v", s, i+1)
+		}
+
+		if !column.Valid() {
+			return invalid("scheme %q has unknown column %q", s, column)
+		}
+
+		if column != SchemeNone && seen[column] {
+			return invalid("scheme %q has column %q more than once", s, column)
+		}
+		seen[column] = true
+
+		switch column {
+		case SchemeIdentifier, SchemeSourcePhrase, SchemeSourceOrTranslation:
+			hasKey = true
+		}
+	}
+
+	if !hasKey {
+		return invalid("scheme %q should have %q, %q or %q column", s, SchemeIdentifier, SchemeSourcePhrase, SchemeSourceOrTranslation)
+	}
+
+	return nil
+}
+
+// validateFiles checks that there is something to upload and that every file has a name in the project.
+func validateFiles(files map[string]string, sources map[string]FileSource) error {
+
+	if len(files) == 0 && len(sources) == 0 {
+		return invalid("Files can't be empty")
+	}
+
+	for name, path := range files {
+		if name == "" {
+			return invalid("file %q has no name in the project", path)
+		}
+		if path == "" {
+			return invalid("file %q has no local path", name)
+		}
+	}
+
+	for name, source := range sources {
+		if name == "" {
+			return invalid("source has no name in the project")
+		}
+		if source == nil {
+			return invalid("file %q has no source", name)
+		}
+	}
+
+	return nil
+}
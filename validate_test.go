@@ -0,0 +1,76 @@
+package crowdin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNewScheme(t *testing.T) {
+	scheme := NewScheme(SchemeIdentifier, SchemeSourceOrTranslation, SchemeContext)
+	if scheme != "identifier,source_or_translation,context" {
+		t.Errorf("Expected %v, got %v", "identifier,source_or_translation,context", scheme)
+	}
+	if err := scheme.Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if columns := scheme.Columns(); len(columns) != 3 || columns[2] != SchemeContext {
+		t.Errorf("Unexpected columns %v", columns)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	files := map[string]string{"strings.csv": "strings.csv"}
+
+	tests := []struct {
+		name    string
+		options interface{ Validate() error }
+		valid   bool
+	}{
+		{"add file", &AddFileOptions{Type: FileTypeCSV, Scheme: "identifier,source_phrase", Files: files}, true},
+		{"add file upper case type", &AddFileOptions{Type: "Symbian", Files: files}, true},
+		{"add file without files", &AddFileOptions{Type: FileTypeCSV}, false},
+		{"add file nil", (*AddFileOptions)(nil), false},
+		{"unknown type", &AddFileOptions{Type: "cvs", Files: files}, false},
+		{"unknown column", &UpdateFileOptions{Scheme: "identifier,sourse_phrase", Files: files}, false},
+		{"empty column", &UpdateFileOptions{Scheme: "identifier,,context", Files: files}, false},
+		{"duplicate column", &UpdateFileOptions{Scheme: "identifier,context,context", Files: files}, false},
+		{"repeated none", &UpdateFileOptions{Scheme: "identifier,none,none", Files: files}, true},
+		{"no identifier", &UpdateFileOptions{Scheme: "translation,context", Files: files}, false},
+		{"upload", &UploadTranslationsOptions{Language: "ru", ImportDuplicates: "1", Files: files}, true},
+		{"upload without language", &UploadTranslationsOptions{Files: files}, false},
+		{"upload duplicates", &UploadTranslationsOptions{Language: "ru", ImportDuplicates: "true", Files: files}, false},
+		{"create project", &CreateProjectOptions{Name: "n", Identifier: "i", SourceLanguage: "en", JoinPolicy: JoinPolicyPrivate}, true},
+		{"create project policy", &CreateProjectOptions{Name: "n", Identifier: "i", SourceLanguage: "en", JoinPolicy: "closed"}, false},
+		{"create project identifier", &CreateProjectOptions{Name: "n", SourceLanguage: "en"}, false},
+		{"edit project", &EditProjectOptions{JoinPolicy: "public"}, false},
+		{"change directory", &ChangeDirectoryOptions{NewName: "new"}, false},
+	}
+
+	for _, test := range tests {
+		err := test.options.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%v: expected no error, got %v", test.name, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%v: expected %v, got %v", test.name, ErrInvalidOptions, err)
+		}
+	}
+}
+
+func TestCrowdin_AddFile_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/project-name/add-file", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request")
+	})
+
+	_, err := crowdin.AddFile(&AddFileOptions{
+		Type:  "cvs",
+		Files: map[string]string{"strings.csv": "strings.csv"},
+	})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected %v, got %v", ErrInvalidOptions, err)
+	}
+}